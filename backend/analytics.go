@@ -0,0 +1,525 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/your-username/shark-tank-analytics/geo"
+	"github.com/your-username/shark-tank-analytics/ml"
+	"github.com/your-username/shark-tank-analytics/models"
+	"github.com/your-username/shark-tank-analytics/stats"
+)
+
+// analyticsMetric is one aggregate over a group of deals. Compute reports false
+// when the metric is undefined for the group, e.g. an average over no deals,
+// and the value is returned as null rather than a misleading 0.
+type analyticsMetric struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	compute     func(deals []models.Deal) (float64, bool)
+}
+
+// analyticsDimension is a way of grouping deals. A deal can fall in several
+// groups of a dimension, e.g. one per shark that invested in it.
+type analyticsDimension struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	keys        func(deal models.Deal) []string
+}
+
+// Metric catalog of the analytics endpoint. Capital and deal metrics only count
+// funded pitches, i.e. a "funded" status or a deal amount above 0. Valuations
+// are the valuation asked for on the show, derived from amount and equity when
+// the sheet has none.
+var analyticsMetrics = []analyticsMetric{
+	{"count", "Number of pitches", func(deals []models.Deal) (float64, bool) {
+		return float64(len(deals)), true
+	}},
+	{"deals", "Number of funded pitches", func(deals []models.Deal) (float64, bool) {
+		return float64(len(fundedDeals(deals))), true
+	}},
+	{"deal_rate", "Share of pitches that were funded", func(deals []models.Deal) (float64, bool) {
+		if len(deals) == 0 {
+			return 0, false
+		}
+		return float64(len(fundedDeals(deals))) / float64(len(deals)), true
+	}},
+	{"total_capital", "Sum of deal amounts, including debt", func(deals []models.Deal) (float64, bool) {
+		total := 0.0
+		for _, deal := range fundedDeals(deals) {
+			total += deal.DealAmount
+		}
+		return total, true
+	}},
+	{"total_debt", "Sum of the debt part of deals", func(deals []models.Deal) (float64, bool) {
+		total := 0.0
+		for _, deal := range fundedDeals(deals) {
+			total += deal.DealDebt
+		}
+		return total, true
+	}},
+	{"avg_deal_amount", "Mean deal amount of funded pitches", func(deals []models.Deal) (float64, bool) {
+		return meanOf(fundedDeals(deals), func(d models.Deal) float64 { return d.DealAmount })
+	}},
+	{"median_deal_amount", "Median deal amount of funded pitches", func(deals []models.Deal) (float64, bool) {
+		return medianOf(fundedDeals(deals), func(d models.Deal) float64 { return d.DealAmount })
+	}},
+	{"avg_deal_equity", "Mean equity given up in funded pitches", func(deals []models.Deal) (float64, bool) {
+		return meanOf(fundedDeals(deals), func(d models.Deal) float64 { return d.DealEquity })
+	}},
+	{"avg_ask_amount", "Mean amount asked for", func(deals []models.Deal) (float64, bool) {
+		return meanOf(deals, func(d models.Deal) float64 { return d.AskAmount })
+	}},
+	{"avg_ask_equity", "Mean equity offered in the ask", func(deals []models.Deal) (float64, bool) {
+		return meanOf(deals, func(d models.Deal) float64 { return d.AskEquity })
+	}},
+	{"median_ask_multiple", "Median asked valuation over current revenue", func(deals []models.Deal) (float64, bool) {
+		multiples := multiplesOf(deals, askMultiple)
+		return stats.Median(multiples), len(multiples) > 0
+	}},
+	{"median_accepted_multiple", "Median deal valuation over current revenue of funded pitches", func(deals []models.Deal) (float64, bool) {
+		multiples := multiplesOf(deals, acceptedMultiple)
+		return stats.Median(multiples), len(multiples) > 0
+	}},
+	{"avg_profit_margin", "Mean profit margin in percent of pitches that reported one", func(deals []models.Deal) (float64, bool) {
+		margins := make([]float64, 0, len(deals))
+		for _, deal := range deals {
+			if deal.ProfitMargin != nil {
+				margins = append(margins, *deal.ProfitMargin)
+			}
+		}
+		return stats.Mean(margins), len(margins) > 0
+	}},
+	{"avg_valuation", "Mean asked valuation", func(deals []models.Deal) (float64, bool) {
+		return meanOf(deals, ml.AskValuation)
+	}},
+	{"median_valuation", "Median asked valuation", func(deals []models.Deal) (float64, bool) {
+		return medianOf(deals, ml.AskValuation)
+	}},
+}
+
+// Dimensions in which a deal can fall in several groups
+var multiValuedDimensions = map[string]bool{
+	"shark":            true,
+	"investor":         true,
+	"founder_age_band": true,
+}
+
+// Dimensions deals can be grouped and filtered by
+var analyticsDimensions = []analyticsDimension{
+	{"season", "Season number", func(deal models.Deal) []string {
+		return []string{strconv.Itoa(deal.Season)}
+	}},
+	{"episode", "Season and episode, e.g. 3x12", func(deal models.Deal) []string {
+		return []string{fmt.Sprintf("%dx%d", deal.Season, deal.Episode)}
+	}},
+	{"industry", "Industry", func(deal models.Deal) []string {
+		return []string{labelOrUnknown(deal.Industry)}
+	}},
+	{"product_category", "Product category", func(deal models.Deal) []string {
+		return []string{labelOrUnknown(deal.ProductCategory)}
+	}},
+	{"state", "State or union territory of the startup", func(deal models.Deal) []string {
+		return []string{labelOrUnknown(deal.State)}
+	}},
+	{"city", "City of the startup", func(deal models.Deal) []string {
+		return []string{labelOrUnknown(deal.City)}
+	}},
+	{"city_tier", "Tier 1, Tier 2, Tier 3 or Unknown", func(deal models.Deal) []string {
+		return []string{geo.TierName(deal.CityTier)}
+	}},
+	{"team_size", "Employees at pitch time, banded", func(deal models.Deal) []string {
+		return []string{teamSizeBand(deal.TeamSize)}
+	}},
+	{"company_age", "Years from founding to the season's air year, banded", func(deal models.Deal) []string {
+		return []string{companyAgeBand(deal)}
+	}},
+	{"founder_count", "Number of founders: 1, 2, 3+ or Unknown", func(deal models.Deal) []string {
+		return []string{founderCount(deal.Founders)}
+	}},
+	{"founder_gender", "Gender mix of the founders", func(deal models.Deal) []string {
+		return []string{founderGenderMix(deal.Founders)}
+	}},
+	{"founder_age_band", "Age band of a founder, a deal counts once for each band among its founders", func(deal models.Deal) []string {
+		return founderAgeBands(deal.Founders)
+	}},
+	{"repeat_founder", "Whether any founder had founded a company before", func(deal models.Deal) []string {
+		return []string{repeatFounder(deal.Founders)}
+	}},
+	{"revenue_stage", "Current revenue: pre-revenue, under 1 crore, 1-10 crore, 10 crore+ or Unknown", func(deal models.Deal) []string {
+		return []string{revenueStage(deal)}
+	}},
+	{"profitability", "profitable, break-even, loss-making or Unknown", func(deal models.Deal) []string {
+		return []string{profitability(deal)}
+	}},
+	{"outcome", "funded or not_funded", func(deal models.Deal) []string {
+		if ml.Funded(deal) {
+			return []string{"funded"}
+		}
+		return []string{"not_funded"}
+	}},
+	{"shark", "Shark on the panel when the pitch aired, a pitch counts once for each shark present; pitches from episodes without a roster are left out", func(deal models.Deal) []string {
+		return deal.Panel
+	}},
+	{"investor", "Shark that invested, a deal counts once for each of its sharks", func(deal models.Deal) []string {
+		sharks := make([]string, 0, len(deal.InvestedSharks))
+		for _, shark := range deal.InvestedSharks {
+			if shark = strings.TrimSpace(shark); shark != "" {
+				sharks = append(sharks, shark)
+			}
+		}
+		return sharks
+	}},
+}
+
+// getAnalytics aggregates deals by any of the analytics dimensions, e.g.
+// /api/analytics?group_by=season,industry&metrics=count,deal_rate&season=3.
+// Every dimension doubles as a filter, and all metrics are returned when none
+// are asked for.
+func getAnalytics(c *gin.Context) {
+	result, err := runAnalytics(analyticsFilters(c), splitParam(c.DefaultQuery("group_by", "")), splitParam(c.DefaultQuery("metrics", "")))
+	if err == errInvalidQuery {
+		c.JSON(http.StatusBadRequest, gin.H{"error": result["error"]})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+// getAnalyticsMetrics returns the metric and dimension catalog
+func getAnalyticsMetrics(c *gin.Context) {
+	c.JSON(http.StatusOK, gin.H{
+		"metrics":    analyticsMetrics,
+		"dimensions": analyticsDimensions,
+	})
+}
+
+// Helper functions
+
+// errInvalidQuery is returned by runAnalytics for an unknown dimension or
+// metric, the result then holds the reason under "error"
+var errInvalidQuery = errors.New("invalid analytics query")
+
+// runAnalytics filters and groups the stored deals and computes the metrics of
+// each group. It backs both the analytics endpoint and saved reports.
+func runAnalytics(filters map[string]string, groupBy, metricNames []string) (gin.H, error) {
+	dimensions, err := analyticsDimensionsFor(strings.Join(groupBy, ","))
+	if err != nil {
+		return gin.H{"error": err.Error()}, errInvalidQuery
+	}
+	metrics, err := analyticsMetricsFor(strings.Join(metricNames, ","))
+	if err != nil {
+		return gin.H{"error": err.Error()}, errInvalidQuery
+	}
+	for name := range filters {
+		if _, ok := findDimension(name); !ok {
+			return gin.H{"error": fmt.Sprintf("unknown filter %q", name)}, errInvalidQuery
+		}
+	}
+
+	deals, err := loadDeals("")
+	if err != nil {
+		return nil, err
+	}
+	deals = applyFilters(deals, filters)
+
+	groups := make(map[string][]models.Deal)
+	groupKeys := make(map[string][]string)
+	for _, deal := range deals {
+		for _, keys := range groupKeysFor(deal, dimensions) {
+			id := strings.Join(keys, "\x00")
+			groups[id] = append(groups[id], deal)
+			groupKeys[id] = keys
+		}
+	}
+
+	ids := make([]string, 0, len(groups))
+	for id := range groups {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool {
+		return lessKeys(groupKeys[ids[i]], groupKeys[ids[j]])
+	})
+
+	rows := make([]gin.H, 0, len(ids))
+	for _, id := range ids {
+		row := computeMetrics(groups[id], metrics)
+		for i, dimension := range dimensions {
+			row[dimension.Name] = groupKeys[id][i]
+		}
+		rows = append(rows, row)
+	}
+
+	names := make([]string, len(dimensions))
+	for i, dimension := range dimensions {
+		names[i] = dimension.Name
+	}
+
+	result := gin.H{
+		"group_by": names,
+		"filters":  filters,
+		"overall":  computeMetrics(deals, metrics),
+		"groups":   rows,
+	}
+	if len(dimensions) > 0 && hasMetric(metrics, "deal_rate") {
+		grouped := make([][]models.Deal, len(ids))
+		for i, id := range ids {
+			grouped[i] = groups[id]
+		}
+		result["comparison"] = compareDealRates(grouped, dimensions)
+	}
+	return result, nil
+}
+
+func analyticsDimensionsFor(param string) ([]analyticsDimension, error) {
+	dimensions := make([]analyticsDimension, 0)
+	for _, name := range splitParam(param) {
+		dimension, ok := findDimension(name)
+		if !ok {
+			return nil, fmt.Errorf("unknown group_by dimension %q", name)
+		}
+		dimensions = append(dimensions, dimension)
+	}
+	return dimensions, nil
+}
+
+func analyticsMetricsFor(param string) ([]analyticsMetric, error) {
+	names := splitParam(param)
+	if len(names) == 0 {
+		return analyticsMetrics, nil
+	}
+
+	metrics := make([]analyticsMetric, 0, len(names))
+	for _, name := range names {
+		found := false
+		for _, metric := range analyticsMetrics {
+			if metric.Name == name {
+				metrics = append(metrics, metric)
+				found = true
+				break
+			}
+		}
+		if !found {
+			return nil, fmt.Errorf("unknown metric %q", name)
+		}
+	}
+	return metrics, nil
+}
+
+func findDimension(name string) (analyticsDimension, bool) {
+	for _, dimension := range analyticsDimensions {
+		if dimension.Name == name {
+			return dimension, true
+		}
+	}
+	return analyticsDimension{}, false
+}
+
+// filterDeals keeps the deals matching every dimension given as a query
+// parameter, comparing case-insensitively. It returns the filters applied.
+func filterDeals(c *gin.Context, deals []models.Deal) ([]models.Deal, map[string]string) {
+	filters := analyticsFilters(c)
+	return applyFilters(deals, filters), filters
+}
+
+// analyticsFilters reads every dimension given as a query parameter
+func analyticsFilters(c *gin.Context) map[string]string {
+	filters := make(map[string]string)
+	for _, dimension := range analyticsDimensions {
+		if value := strings.TrimSpace(c.DefaultQuery(dimension.Name, "")); value != "" {
+			filters[dimension.Name] = value
+		}
+	}
+	return filters
+}
+
+// applyFilters keeps the deals matching every filter, keyed by dimension name
+func applyFilters(deals []models.Deal, filters map[string]string) []models.Deal {
+	filtered := make([]models.Deal, 0, len(deals))
+	for _, deal := range deals {
+		matches := true
+		for name, value := range filters {
+			dimension, _ := findDimension(name)
+			if !containsFold(dimension.keys(deal), value) {
+				matches = false
+				break
+			}
+		}
+		if matches {
+			filtered = append(filtered, deal)
+		}
+	}
+	return filtered
+}
+
+// groupKeysFor returns every combination of dimension keys a deal falls in
+func groupKeysFor(deal models.Deal, dimensions []analyticsDimension) [][]string {
+	combinations := [][]string{{}}
+	for _, dimension := range dimensions {
+		next := make([][]string, 0)
+		for _, combination := range combinations {
+			for _, key := range dimension.keys(deal) {
+				keys := append(append([]string(nil), combination...), key)
+				next = append(next, keys)
+			}
+		}
+		combinations = next
+	}
+	return combinations
+}
+
+// computeMetrics computes the metrics of a group. Every group is flagged when
+// it has too few pitches to read much into, and a deal rate comes with its 95%
+// Wilson interval.
+func computeMetrics(deals []models.Deal, metrics []analyticsMetric) gin.H {
+	row := gin.H{"small_sample": len(deals) < stats.MinSampleSize}
+	for _, metric := range metrics {
+		if value, ok := metric.compute(deals); ok {
+			row[metric.Name] = value
+		} else {
+			row[metric.Name] = nil
+		}
+		if metric.Name == "deal_rate" {
+			row["deal_rate_interval"] = stats.NewRate(len(fundedDeals(deals)), len(deals))
+		}
+	}
+	return row
+}
+
+// compareDealRates tests whether deal rates differ between groups. Groups of a
+// multi-valued dimension such as shark share deals, which breaks the tests'
+// independence assumption, so the result says so.
+func compareDealRates(groups [][]models.Deal, dimensions []analyticsDimension) gin.H {
+	funded := make([]int, len(groups))
+	totals := make([]int, len(groups))
+	for i, group := range groups {
+		funded[i] = len(fundedDeals(group))
+		totals[i] = len(group)
+	}
+
+	overlapping := false
+	for _, dimension := range dimensions {
+		if multiValuedDimensions[dimension.Name] {
+			overlapping = true
+		}
+	}
+
+	return gin.H{
+		"deal_rate":          stats.CompareRates(funded, totals),
+		"overlapping_groups": overlapping,
+	}
+}
+
+func hasMetric(metrics []analyticsMetric, name string) bool {
+	for _, metric := range metrics {
+		if metric.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+func fundedDeals(deals []models.Deal) []models.Deal {
+	funded := make([]models.Deal, 0, len(deals))
+	for _, deal := range deals {
+		if ml.Funded(deal) {
+			funded = append(funded, deal)
+		}
+	}
+	return funded
+}
+
+// positiveValues extracts a field, skipping deals where it is missing
+func positiveValues(deals []models.Deal, value func(models.Deal) float64) []float64 {
+	values := make([]float64, 0, len(deals))
+	for _, deal := range deals {
+		if v := value(deal); v > 0 {
+			values = append(values, v)
+		}
+	}
+	return values
+}
+
+func meanOf(deals []models.Deal, value func(models.Deal) float64) (float64, bool) {
+	values := positiveValues(deals, value)
+	return stats.Mean(values), len(values) > 0
+}
+
+func medianOf(deals []models.Deal, value func(models.Deal) float64) (float64, bool) {
+	values := positiveValues(deals, value)
+	return stats.Median(values), len(values) > 0
+}
+
+// lessKeys orders group keys with numbers compared by value, so that season 2
+// comes before season 10 and episode 3x2 before 3x12
+func lessKeys(a, b []string) bool {
+	for i := range a {
+		if a[i] != b[i] {
+			return naturalLess(a[i], b[i])
+		}
+	}
+	return false
+}
+
+func naturalLess(a, b string) bool {
+	for a != "" && b != "" {
+		na, nb := leadingDigits(a), leadingDigits(b)
+		if na != "" && nb != "" {
+			x, _ := strconv.Atoi(na)
+			y, _ := strconv.Atoi(nb)
+			if x != y {
+				return x < y
+			}
+			a, b = a[len(na):], b[len(nb):]
+			continue
+		}
+		if a[0] != b[0] {
+			return a[0] < b[0]
+		}
+		a, b = a[1:], b[1:]
+	}
+	return len(a) < len(b)
+}
+
+func leadingDigits(s string) string {
+	i := 0
+	for i < len(s) && s[i] >= '0' && s[i] <= '9' {
+		i++
+	}
+	return s[:i]
+}
+
+func labelOrUnknown(label string) string {
+	if label = strings.TrimSpace(label); label != "" {
+		return label
+	}
+	return "Unknown"
+}
+
+func splitParam(param string) []string {
+	values := make([]string, 0)
+	for _, value := range strings.Split(param, ",") {
+		if value = strings.TrimSpace(value); value != "" {
+			values = append(values, value)
+		}
+	}
+	return values
+}
+
+func containsFold(values []string, value string) bool {
+	for _, v := range values {
+		if strings.EqualFold(v, value) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,158 @@
+package main
+
+import (
+	"database/sql"
+	"flag"
+	"log"
+	"strings"
+
+	"github.com/your-username/shark-tank-analytics/handlers"
+	"github.com/your-username/shark-tank-analytics/ml"
+	"github.com/your-username/shark-tank-analytics/models"
+	"github.com/your-username/shark-tank-analytics/risk"
+)
+
+// backfillPredictions implements the backfill subcommand: it scores every
+// stored deal the model version was not trained on and replaces that
+// version's predictions
+func backfillPredictions(args []string) {
+	flags := flag.NewFlagSet("backfill", flag.ExitOnError)
+	version := flags.String("version", "", "model version to score with, defaults to the served version")
+	flags.Parse(args)
+
+	if *version == "" {
+		v, err := modelVersion()
+		if err != nil {
+			log.Fatal(err)
+		}
+		*version = v
+	}
+
+	model, err := ml.Load(modelPath(*version))
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	deals, err := loadDeals("")
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	count, err := writePredictions(model, deals)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	log.Printf("Backfilled %d predictions with model %s", count, model.Version)
+}
+
+// writePredictions replaces the stored predictions of the model's version with
+// fresh predictions for the given deals it has not seen
+func writePredictions(model *ml.Model, deals []models.Deal) (int, error) {
+	deals = unseenDeals(model, deals)
+
+	tx, err := db.Begin()
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.Exec(`DELETE FROM predictions WHERE model_version = ?`, model.Version); err != nil {
+		return 0, err
+	}
+	if err := insertPredictions(tx, model, deals); err != nil {
+		return 0, err
+	}
+
+	return len(deals), tx.Commit()
+}
+
+// ensurePredictions stores predictions from the model for every deal it has
+// not seen that does not have one from the model's version yet
+func ensurePredictions(model *ml.Model) (int, error) {
+	deals, err := loadDeals("id NOT IN (SELECT deal_id FROM predictions WHERE model_version = ?)", model.Version)
+	if err != nil {
+		return 0, err
+	}
+	deals = unseenDeals(model, deals)
+	if len(deals) == 0 {
+		return 0, nil
+	}
+
+	tx, err := db.Begin()
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback()
+
+	if err := insertPredictions(tx, model, deals); err != nil {
+		return 0, err
+	}
+
+	return len(deals), tx.Commit()
+}
+
+// unseenDeals drops the deals the model was trained on. Predictions for those
+// are in-sample and would flatter the accuracy measured once outcomes are known.
+func unseenDeals(model *ml.Model, deals []models.Deal) []models.Deal {
+	unseen := make([]models.Deal, 0, len(deals))
+	for _, deal := range deals {
+		if !model.Seen(deal.ID) {
+			unseen = append(unseen, deal)
+		}
+	}
+	return unseen
+}
+
+func insertPredictions(tx *sql.Tx, model *ml.Model, deals []models.Deal) error {
+	stmt, err := tx.Prepare(`
+		INSERT INTO predictions (
+			deal_id, success_probability, predicted_equity, predicted_amount,
+			predicted_valuation, valuation_low, valuation_high, risk_score,
+			model_version
+		) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)
+	`)
+	if err != nil {
+		return err
+	}
+	defer stmt.Close()
+
+	for _, deal := range deals {
+		p := model.Predict(deal)
+		_, err := stmt.Exec(
+			deal.ID, p.DealProbability, p.ExpectedEquity, p.ExpectedAmount,
+			p.CounterValuation, p.ValuationInterval.Low, p.ValuationInterval.High,
+			risk.Score(deal, handlers.RiskConfig).Score, p.ModelVersion,
+		)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// logSeasonDrift reports how far newly imported seasons have drifted from
+// the seasons before them
+func logSeasonDrift(model *ml.Model, seasons []int) {
+	if len(seasons) == 0 {
+		return
+	}
+
+	deals, err := loadDeals("")
+	if err != nil {
+		log.Printf("Error loading deals for drift: %v", err)
+		return
+	}
+
+	for _, season := range seasons {
+		report := handlers.SeasonDrift(model, deals, season)
+		if report.BaselineDeals == 0 {
+			continue
+		}
+		log.Printf("Season %d drift: prediction PSI %.3f, mean prediction %.3f vs %.3f before",
+			season, report.PredictionPSI, report.CurrentMeanPrediction, report.BaselineMeanPrediction)
+		if len(report.Flagged) > 0 {
+			log.Printf("Season %d has drifted on: %s", season, strings.Join(report.Flagged, ", "))
+		}
+	}
+}
@@ -0,0 +1,81 @@
+package main
+
+import (
+	"flag"
+	"log"
+
+	"github.com/your-username/shark-tank-analytics/handlers"
+	"github.com/your-username/shark-tank-analytics/ml"
+)
+
+// runBacktest implements the backtest subcommand: it predicts every season
+// from the seasons before it, training with the options of a model version,
+// and stores the results under that version
+func runBacktest(args []string) {
+	flags := flag.NewFlagSet("backtest", flag.ExitOnError)
+	version := flags.String("version", "", "model version to backtest and file the results under, defaults to the served version")
+	flags.Parse(args)
+
+	if *version == "" {
+		v, err := modelVersion()
+		if err != nil {
+			log.Fatal(err)
+		}
+		*version = v
+	}
+
+	deals, err := loadDeals("")
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	// Retrain the way the version was trained, so its results describe it
+	model, err := ml.Load(modelPath(*version))
+	if err != nil {
+		log.Fatal(err)
+	}
+	opts, err := model.TrainingOptions()
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	results, err := ml.Backtest(deals, opts)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	records, err := handlers.BacktestRecords(*version, results)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	tx, err := db.Begin()
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.Exec(`DELETE FROM backtests WHERE model_version = ?`, *version); err != nil {
+		log.Fatal(err)
+	}
+	for _, r := range records {
+		_, err := tx.Exec(`
+			INSERT INTO backtests (
+				model_version, run_at, season, training_size, deals,
+				accuracy, brier_score, auc, calibration, industries
+			) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+		`, r.ModelVersion, r.RunAt, r.Season, r.TrainingSize, r.Deals,
+			r.Accuracy, r.BrierScore, r.AUC, string(r.Calibration), string(r.Industries))
+		if err != nil {
+			log.Fatal(err)
+		}
+	}
+	if err := tx.Commit(); err != nil {
+		log.Fatal(err)
+	}
+
+	for _, r := range results {
+		log.Printf("Season %d: %d deals, accuracy %.3f, Brier score %.3f, AUC %.3f", r.Season, r.Deals, r.Accuracy, r.BrierScore, r.AUC)
+	}
+	log.Printf("Stored %d season backtests for model %s", len(records), *version)
+}
@@ -0,0 +1,219 @@
+package main
+
+import (
+	"math"
+	"net/http"
+	"sort"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/your-username/shark-tank-analytics/ml"
+	"github.com/your-username/shark-tank-analytics/models"
+	"github.com/your-username/shark-tank-analytics/stats"
+)
+
+// Average days per month, for reporting durations in months
+const daysPerMonth = 30.44
+
+// How far a revenue snapshot may be from the 1 or 2 year mark to count for it
+const snapshotTolerance = 90 * 24 * time.Hour
+
+// getCohorts tracks startups after the show grouped by the season they
+// appeared in: the share that raised follow-on funding, the median time from
+// air date to their next round, and median revenue growth 1 and 2 years after
+// the show measured from their revenue at pitch time.
+//
+// Only funded startups are included unless include=all. Air dates come from
+// the episodes table, falling back to the start of the season; startups dated
+// only by their season count towards the 1 and 2 year shares but not towards
+// the months to the next round. Follow-on funding is a dated round on or after
+// the air date, so the share is over startups with an air date, and startups
+// whose only rounds after the show might be undated ones are reported apart. Shares and growth at a horizon only count
+// startups whose air date is at least that long ago
+// and that reported revenue within three months of the mark, and every
+// statistic comes with the number of startups it is based on.
+func getCohorts(c *gin.Context) {
+	deals, err := loadDeals("")
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	deals, filters := filterDeals(c, deals)
+	if c.DefaultQuery("include", "funded") != "all" {
+		deals = fundedDeals(deals)
+	}
+
+	bySeason := make(map[int][]models.Deal)
+	for _, deal := range deals {
+		bySeason[deal.Season] = append(bySeason[deal.Season], deal)
+	}
+	seasons := make([]int, 0, len(bySeason))
+	for season := range bySeason {
+		seasons = append(seasons, season)
+	}
+	sort.Ints(seasons)
+
+	now := time.Now().UTC()
+	cohorts := make([]gin.H, 0, len(seasons))
+	followOn := make([]int, len(seasons))
+	totals := make([]int, len(seasons))
+	for i, season := range seasons {
+		cohorts = append(cohorts, seasonCohort(season, bySeason[season], now))
+		followOn[i] = cohorts[i]["follow_on"].(int)
+		totals[i] = cohorts[i]["dated_startups"].(int)
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"filters":    filters,
+		"cohorts":    cohorts,
+		"comparison": gin.H{"follow_on_share": stats.CompareRates(followOn, totals)},
+	})
+}
+
+// Helper functions
+
+func seasonCohort(season int, deals []models.Deal, now time.Time) gin.H {
+	followOn, undated, dated := 0, 0, 0
+	within1y, within2y := 0, 0
+	observable1y, observable2y, approximate := 0, 0, 0
+	var monthsToRound, growth1y, growth2y, reportedGrowth []float64
+
+	for _, deal := range deals {
+		status := deal.PostShowStatus
+
+		if status.RevenueGrowth != 0 {
+			reportedGrowth = append(reportedGrowth, status.RevenueGrowth)
+		}
+		if deal.AirDate == nil {
+			continue
+		}
+		aired := *deal.AirDate
+		dated++
+		if !deal.AirDateExact {
+			approximate++
+		}
+
+		// Rounds before the show are not follow-on funding, and undated
+		// rounds cannot be told apart from them
+		next, raised := nextRound(status.FundingRounds, aired)
+		if !raised && hasUndatedRound(status.FundingRounds) {
+			undated++
+		}
+		months := 0.0
+		if raised {
+			followOn++
+			months = next.Sub(aired).Hours() / 24 / daysPerMonth
+			// Counted from the season's start the months could be off by the
+			// length of the season
+			if deal.AirDateExact {
+				monthsToRound = append(monthsToRound, months)
+			}
+		}
+
+		if !aired.AddDate(1, 0, 0).After(now) {
+			observable1y++
+			if raised && months <= 12 {
+				within1y++
+			}
+			if g, ok := growthAt(deal, aired.AddDate(1, 0, 0)); ok {
+				growth1y = append(growth1y, g)
+			}
+		}
+		if !aired.AddDate(2, 0, 0).After(now) {
+			observable2y++
+			if raised && months <= 24 {
+				within2y++
+			}
+			if g, ok := growthAt(deal, aired.AddDate(2, 0, 0)); ok {
+				growth2y = append(growth2y, g)
+			}
+		}
+	}
+
+	return gin.H{
+		"season":                   season,
+		"startups":                 len(deals),
+		"dated_startups":           dated,
+		"follow_on":                followOn,
+		"follow_on_share":          share(followOn, dated),
+		"follow_on_interval":       stats.NewRate(followOn, dated),
+		"undated_rounds":           undated,
+		"follow_on_within_1y":      share(within1y, observable1y),
+		"follow_on_within_2y":      share(within2y, observable2y),
+		"small_sample":             dated < stats.MinSampleSize,
+		"median_months_to_round":   medianOrNull(monthsToRound),
+		"dated_rounds":             len(monthsToRound),
+		"approximate_air_dates":    approximate,
+		"median_growth_1y":         medianOrNull(growth1y),
+		"growth_1y_reported":       len(growth1y),
+		"observable_1y":            observable1y,
+		"median_growth_2y":         medianOrNull(growth2y),
+		"growth_2y_reported":       len(growth2y),
+		"observable_2y":            observable2y,
+		"median_reported_growth":   medianOrNull(reportedGrowth),
+		"reported_growth_startups": len(reportedGrowth),
+	}
+}
+
+// nextRound is the date of the first dated round on or after the air date
+func nextRound(rounds []models.FundingRound, aired time.Time) (time.Time, bool) {
+	var next time.Time
+	for _, round := range rounds {
+		if round.Date.IsZero() || round.Date.Before(aired) {
+			continue
+		}
+		if next.IsZero() || round.Date.Before(next) {
+			next = round.Date
+		}
+	}
+	return next, !next.IsZero()
+}
+
+// hasUndatedRound reports whether any of the rounds has no date
+func hasUndatedRound(rounds []models.FundingRound) bool {
+	for _, round := range rounds {
+		if round.Date.IsZero() {
+			return true
+		}
+	}
+	return false
+}
+
+// growthAt is revenue growth from pitch time to the snapshot closest to mark,
+// e.g. 0.5 for 50% higher revenue
+func growthAt(deal models.Deal, mark time.Time) (float64, bool) {
+	revenue := ml.Revenue(deal)
+	if revenue <= 0 {
+		return 0, false
+	}
+
+	var closest *models.RevenueSnapshot
+	distance := time.Duration(math.MaxInt64)
+	for i, snapshot := range deal.PostShowStatus.RevenueHistory {
+		d := snapshot.Date.Sub(mark)
+		if d < 0 {
+			d = -d
+		}
+		if d <= snapshotTolerance && d < distance {
+			closest, distance = &deal.PostShowStatus.RevenueHistory[i], d
+		}
+	}
+	if closest == nil {
+		return 0, false
+	}
+	return closest.Revenue/revenue - 1, true
+}
+
+func share(count, total int) interface{} {
+	if total == 0 {
+		return nil
+	}
+	return float64(count) / float64(total)
+}
+
+func medianOrNull(values []float64) interface{} {
+	if len(values) == 0 {
+		return nil
+	}
+	return stats.Median(values)
+}
@@ -0,0 +1,77 @@
+package main
+
+import (
+	"testing"
+	"time"
+
+	"github.com/your-username/shark-tank-analytics/models"
+)
+
+func date(year int, month time.Month, day int) time.Time {
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+}
+
+func TestSeasonCohortFollowOn(t *testing.T) {
+	aired := date(2022, 1, 10)
+	withRounds := func(rounds ...models.FundingRound) models.Deal {
+		return models.Deal{
+			AirDate:        &aired,
+			AirDateExact:   true,
+			PostShowStatus: models.PostShowStatus{FundingRounds: rounds},
+		}
+	}
+
+	deals := []models.Deal{
+		// Raised 6 months after the show
+		withRounds(models.FundingRound{Round: "Seed", Date: date(2022, 7, 10)}),
+		// Raised before the show only
+		withRounds(models.FundingRound{Round: "Angel", Date: date(2021, 6, 1)}),
+		// Round without a date
+		withRounds(models.FundingRound{Round: "Bridge"}),
+		// Raised 18 months after, with an older pre-show round
+		withRounds(
+			models.FundingRound{Round: "Angel", Date: date(2020, 3, 1)},
+			models.FundingRound{Round: "Series A", Date: date(2023, 7, 10)},
+		),
+		withRounds(),
+		// No air date at all
+		{PostShowStatus: models.PostShowStatus{FundingRounds: []models.FundingRound{{Round: "Seed", Date: date(2023, 1, 1)}}}},
+	}
+
+	cohort := seasonCohort(1, deals, date(2025, 1, 1))
+
+	checks := map[string]interface{}{
+		"startups":            6,
+		"dated_startups":      5,
+		"follow_on":           2,
+		"follow_on_share":     0.4,
+		"undated_rounds":      1,
+		"follow_on_within_1y": 0.2,
+		"follow_on_within_2y": 0.4,
+		"dated_rounds":        2,
+	}
+	for key, want := range checks {
+		if got := cohort[key]; got != want {
+			t.Errorf("%s = %v, want %v", key, got, want)
+		}
+	}
+}
+
+func TestSeasonCohortApproximateAirDates(t *testing.T) {
+	start := date(2022, 1, 1)
+	deal := models.Deal{
+		AirDate: &start,
+		PostShowStatus: models.PostShowStatus{
+			FundingRounds: []models.FundingRound{{Round: "Seed", Date: date(2022, 5, 1)}},
+		},
+	}
+
+	cohort := seasonCohort(1, []models.Deal{deal}, date(2025, 1, 1))
+	if cohort["follow_on"] != 1 || cohort["approximate_air_dates"] != 1 {
+		t.Errorf("follow_on = %v, approximate_air_dates = %v, want 1 and 1", cohort["follow_on"], cohort["approximate_air_dates"])
+	}
+	// Months from a season's start are not reported as months to the round
+	if cohort["dated_rounds"] != 0 || cohort["median_months_to_round"] != nil {
+		t.Errorf("dated_rounds = %v, median_months_to_round = %v, want 0 and nil", cohort["dated_rounds"], cohort["median_months_to_round"])
+	}
+}
@@ -0,0 +1,194 @@
+package main
+
+import (
+	"fmt"
+	"math"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/your-username/shark-tank-analytics/ml"
+	"github.com/your-username/shark-tank-analytics/models"
+	"github.com/your-username/shark-tank-analytics/stats"
+)
+
+// distributionField is a deal value whose distribution can be described. Money
+// fields default to log-scale buckets since a few large asks dwarf the rest.
+type distributionField struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	LogScale    bool   `json:"log_scale"`
+	value       func(deal models.Deal) float64
+	fundedOnly  bool
+}
+
+var distributionFields = []distributionField{
+	{Name: "ask_amount", Description: "Amount asked for", LogScale: true, value: func(d models.Deal) float64 { return d.AskAmount }},
+	{Name: "valuation", Description: "Asked valuation", LogScale: true, value: ml.AskValuation},
+	{Name: "ask_equity", Description: "Equity offered in the ask", value: func(d models.Deal) float64 { return d.AskEquity }},
+	{Name: "deal_amount", Description: "Deal amount of funded pitches", LogScale: true, value: func(d models.Deal) float64 { return d.DealAmount }, fundedOnly: true},
+	{Name: "deal_equity", Description: "Equity given up in funded pitches", value: func(d models.Deal) float64 { return d.DealEquity }, fundedOnly: true},
+}
+
+// Default and maximum number of histogram buckets
+const (
+	defaultBuckets = 10
+	maxBuckets     = 100
+)
+
+// getDistributions describes the distribution of one deal field with
+// percentiles and a histogram, e.g.
+// /api/analytics/distributions?field=valuation&group_by=industry&buckets=8.
+// The buckets are shared by all groups so their histograms line up. They are
+// either given as edges=0,1e6,1e7 or derived from buckets and scale=log|linear.
+// Deals are filtered and grouped by the same dimensions as /api/analytics.
+func getDistributions(c *gin.Context) {
+	field, ok := findDistributionField(c.DefaultQuery("field", "valuation"))
+	if !ok {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "unknown field, use one of " + distributionFieldNames()})
+		return
+	}
+	dimensions, err := analyticsDimensionsFor(c.DefaultQuery("group_by", ""))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	deals, err := loadDeals("")
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	deals, filters := filterDeals(c, deals)
+	if field.fundedOnly {
+		deals = fundedDeals(deals)
+	}
+
+	all := positiveValues(deals, field.value)
+	edges, err := histogramEdges(c, field, all)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	groups := make(map[string][]float64)
+	groupKeys := make(map[string][]string)
+	for _, deal := range deals {
+		v := field.value(deal)
+		if v <= 0 {
+			continue
+		}
+		for _, keys := range groupKeysFor(deal, dimensions) {
+			id := strings.Join(keys, "\x00")
+			groups[id] = append(groups[id], v)
+			groupKeys[id] = keys
+		}
+	}
+
+	ids := make([]string, 0, len(groups))
+	for id := range groups {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool {
+		return lessKeys(groupKeys[ids[i]], groupKeys[ids[j]])
+	})
+
+	rows := make([]gin.H, 0, len(ids))
+	for _, id := range ids {
+		row := gin.H{
+			"summary":   stats.Summarize(groups[id]),
+			"histogram": stats.Histogram(groups[id], edges),
+		}
+		for i, dimension := range dimensions {
+			row[dimension.Name] = groupKeys[id][i]
+		}
+		rows = append(rows, row)
+	}
+
+	names := make([]string, len(dimensions))
+	for i, dimension := range dimensions {
+		names[i] = dimension.Name
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"field":    field,
+		"group_by": names,
+		"filters":  filters,
+		"edges":    edges,
+		"overall": gin.H{
+			"summary":   stats.Summarize(all),
+			"histogram": stats.Histogram(all, edges),
+		},
+		"groups": rows,
+	})
+}
+
+// Helper functions
+
+func findDistributionField(name string) (distributionField, bool) {
+	for _, field := range distributionFields {
+		if field.Name == name {
+			return field, true
+		}
+	}
+	return distributionField{}, false
+}
+
+func distributionFieldNames() string {
+	names := make([]string, len(distributionFields))
+	for i, field := range distributionFields {
+		names[i] = field.Name
+	}
+	return strings.Join(names, ", ")
+}
+
+// histogramEdges reads explicit edges or spreads buckets over the range of values
+func histogramEdges(c *gin.Context, field distributionField, values []float64) ([]float64, error) {
+	if param := c.DefaultQuery("edges", ""); param != "" {
+		parts := splitParam(param)
+		edges := make([]float64, 0, len(parts))
+		for _, part := range parts {
+			edge, err := strconv.ParseFloat(part, 64)
+			if err != nil {
+				return nil, fmt.Errorf("invalid edge %q", part)
+			}
+			edges = append(edges, edge)
+		}
+		if len(edges) < 2 || !sort.Float64sAreSorted(edges) {
+			return nil, fmt.Errorf("edges must be at least two increasing numbers")
+		}
+		return edges, nil
+	}
+
+	buckets, err := strconv.Atoi(c.DefaultQuery("buckets", strconv.Itoa(defaultBuckets)))
+	if err != nil || buckets < 1 || buckets > maxBuckets {
+		return nil, fmt.Errorf("buckets must be between 1 and %d", maxBuckets)
+	}
+
+	if len(values) == 0 {
+		return []float64{}, nil
+	}
+	low, high := math.Inf(1), math.Inf(-1)
+	for _, v := range values {
+		low, high = math.Min(low, v), math.Max(high, v)
+	}
+	if low == high {
+		return []float64{low, high}, nil
+	}
+
+	switch c.DefaultQuery("scale", "") {
+	case "log":
+		return stats.LogEdges(low, high, buckets), nil
+	case "linear":
+		return stats.LinearEdges(low, high, buckets), nil
+	case "":
+		if field.LogScale {
+			return stats.LogEdges(low, high, buckets), nil
+		}
+		return stats.LinearEdges(low, high, buckets), nil
+	default:
+		return nil, fmt.Errorf("scale must be log or linear")
+	}
+}
@@ -0,0 +1,246 @@
+// Package geo normalizes free-text Indian locations to a city, state and city
+// tier using an embedded gazetteer, without any network lookups.
+//
+// Tiers follow the usual RBI-style grouping: 1 for the eight metros, 2 for the
+// other large cities in the gazetteer and 3 for a city outside the gazetteer
+// written next to its state.
+// Tier 0 means the location could not be placed.
+package geo
+
+import (
+	_ "embed"
+	"encoding/json"
+	"sort"
+	"strings"
+	"unicode"
+)
+
+//go:embed gazetteer.json
+var gazetteerJSON []byte
+
+// State is a state or union territory with the coordinates of its centre
+type State struct {
+	Name    string   `json:"name"`
+	Code    string   `json:"code"`
+	Aliases []string `json:"aliases,omitempty"`
+	Lat     float64  `json:"lat"`
+	Lon     float64  `json:"lon"`
+}
+
+// City is a city of the gazetteer
+type City struct {
+	Name    string   `json:"name"`
+	State   string   `json:"state"`
+	Tier    int      `json:"tier"`
+	Aliases []string `json:"aliases,omitempty"`
+	Lat     float64  `json:"lat"`
+	Lon     float64  `json:"lon"`
+}
+
+// Place is a normalized location. Lat and Lon are the city's when it is in the
+// gazetteer and the state's centre otherwise.
+type Place struct {
+	City  string  `json:"city"`
+	State string  `json:"state"`
+	Tier  int     `json:"tier"`
+	Lat   float64 `json:"lat"`
+	Lon   float64 `json:"lon"`
+}
+
+var (
+	states     []State
+	cities     []City
+	stateIndex = make(map[string]State)
+	cityIndex  = make(map[string]City)
+	// State codes such as UP only match a whole part of a location, as they
+	// are also common words
+	codeIndex = make(map[string]State)
+	// Keys of the indexes above, for matching inside longer text
+	cityNames, stateNames []string
+)
+
+func init() {
+	var gazetteer struct {
+		States []State `json:"states"`
+		Cities []City  `json:"cities"`
+	}
+	if err := json.Unmarshal(gazetteerJSON, &gazetteer); err != nil {
+		panic("geo: invalid gazetteer: " + err.Error())
+	}
+
+	states, cities = gazetteer.States, gazetteer.Cities
+	for _, state := range states {
+		stateIndex[key(state.Name)] = state
+		for _, alias := range state.Aliases {
+			stateIndex[key(alias)] = state
+		}
+		codeIndex[key(state.Code)] = state
+	}
+	for _, city := range cities {
+		cityIndex[key(city.Name)] = city
+		for _, alias := range city.Aliases {
+			cityIndex[key(alias)] = city
+		}
+	}
+
+	for name := range cityIndex {
+		cityNames = append(cityNames, name)
+	}
+	for name := range stateIndex {
+		stateNames = append(stateNames, name)
+	}
+	// Ties between equally long names resolve the same way on every run
+	sort.Strings(cityNames)
+	sort.Strings(stateNames)
+}
+
+// States returns every state of the gazetteer
+func States() []State {
+	return states
+}
+
+// LookupState finds a state by name, alias or code
+func LookupState(name string) (State, bool) {
+	if state, ok := stateIndex[key(name)]; ok {
+		return state, true
+	}
+	state, ok := codeIndex[key(name)]
+	return state, ok
+}
+
+// TierName labels a tier for display
+func TierName(tier int) string {
+	switch tier {
+	case 1:
+		return "Tier 1"
+	case 2:
+		return "Tier 2"
+	case 3:
+		return "Tier 3"
+	default:
+		return "Unknown"
+	}
+}
+
+// Normalize places a free-text location such as "Pune, Maharashtra",
+// "Bangalore" or "Indore (MP)". Known cities win over states. Country names and
+// placeholders such as Unknown are ignored, and a state code such as MP or UK
+// only counts next to a known city or state, since on its own it may just as
+// well be a foreign country.
+//
+// A city missing from the gazetteer is taken as a tier 3 city only when the
+// location is written as "City, State": a separate part of at most three
+// words next to a part that names a state.
+func Normalize(location string) Place {
+	parts := strings.FieldsFunc(location, func(r rune) bool {
+		return r == ',' || r == '/' || r == '(' || r == ')' || r == '|' || r == ';'
+	})
+
+	var state *State
+	var unknown []string
+	named := 0
+	for _, part := range parts {
+		k := key(part)
+		if k == "" || countryNames[k] || placeholders[k] {
+			continue
+		}
+		if city, ok := cityIndex[k]; ok {
+			return placeOf(city)
+		}
+		if _, ok := codeIndex[k]; ok {
+			continue
+		}
+		named++
+		if s, ok := stateIndex[k]; ok {
+			if state == nil {
+				state = &s
+			}
+			continue
+		}
+		unknown = append(unknown, part)
+	}
+
+	// Cities and states mentioned inside a longer phrase, e.g. "based in Jaipur"
+	full := " " + key(location) + " "
+	if name, ok := longestMatch(full, cityNames); ok {
+		return placeOf(cityIndex[name])
+	}
+	if state != nil {
+		place := Place{State: state.Name, Lat: state.Lat, Lon: state.Lon}
+		if named == 2 && len(unknown) == 1 && cityLike(unknown[0]) {
+			place.City = titleCase(strings.TrimSpace(unknown[0]))
+			place.Tier = 3
+		}
+		return place
+	}
+	if name, ok := longestMatch(full, stateNames); ok {
+		s := stateIndex[name]
+		return Place{State: s.Name, Lat: s.Lat, Lon: s.Lon}
+	}
+	return Place{}
+}
+
+// countryNames are ignored when placing a location, all places being in India
+var countryNames = map[string]bool{
+	"india":             true,
+	"bharat":            true,
+	"in":                true,
+	"ind":               true,
+	"republic of india": true,
+}
+
+// placeholders stand in for a missing place and are never taken as a city
+var placeholders = map[string]bool{
+	"unknown":       true,
+	"na":            true,
+	"none":          true,
+	"not disclosed": true,
+	"other":         true,
+}
+
+// cityLike reports whether a part of a location can be a city name: at most
+// three words of letters
+func cityLike(part string) bool {
+	words := strings.Fields(part)
+	if len(words) == 0 || len(words) > 3 {
+		return false
+	}
+	for _, r := range part {
+		if !unicode.IsLetter(r) && r != ' ' && r != '.' && r != '-' {
+			return false
+		}
+	}
+	return true
+}
+
+func placeOf(city City) Place {
+	return Place{City: city.Name, State: city.State, Tier: city.Tier, Lat: city.Lat, Lon: city.Lon}
+}
+
+// longestMatch finds the longest name that appears as whole words in text
+func longestMatch(text string, names []string) (string, bool) {
+	best := ""
+	for _, name := range names {
+		if len(name) > len(best) && strings.Contains(text, " "+name+" ") {
+			best = name
+		}
+	}
+	return best, best != ""
+}
+
+// key lowercases a name and reduces punctuation and spacing to single spaces
+func key(s string) string {
+	return strings.Join(strings.FieldsFunc(strings.ToLower(s), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r) && r != '&'
+	}), " ")
+}
+
+func titleCase(s string) string {
+	words := strings.Fields(strings.ToLower(s))
+	for i, word := range words {
+		runes := []rune(word)
+		runes[0] = unicode.ToUpper(runes[0])
+		words[i] = string(runes)
+	}
+	return strings.Join(words, " ")
+}
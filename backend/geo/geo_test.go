@@ -0,0 +1,76 @@
+package geo
+
+import "testing"
+
+func TestNormalize(t *testing.T) {
+	tests := []struct {
+		location string
+		city     string
+		state    string
+		tier     int
+	}{
+		{"Pune, Maharashtra", "Pune", "Maharashtra", 1},
+		{"Bangalore", "Bengaluru", "Karnataka", 1},
+		{"Bombay", "Mumbai", "Maharashtra", 1},
+		{"Gurgaon, Haryana", "Gurugram", "Haryana", 2},
+		{"Indore (MP)", "Indore", "Madhya Pradesh", 2},
+		{"based in New Delhi", "Delhi", "Delhi", 1},
+		{"Mumbai, India", "Mumbai", "Maharashtra", 1},
+		// A state code counts next to a known city
+		{"Dehradun, UK", "Dehradun", "Uttarakhand", 2},
+		// Cities outside the gazetteer only as "City, State"
+		{"Sangli, Maharashtra", "Sangli", "Maharashtra", 3},
+		{"Some long company name here, Goa", "", "Goa", 0},
+		{"Startup in Goa", "", "Goa", 0},
+		{"Kerala", "", "Kerala", 0},
+		{"Maharashtra, India", "", "Maharashtra", 0},
+		{"Unknown, Maharashtra", "", "Maharashtra", 0},
+		// Country names and lone state codes place nothing
+		{"London, UK", "", "", 0},
+		{"UP", "", "", 0},
+		{"India", "", "", 0},
+		{"Unknown town", "", "", 0},
+		{"", "", "", 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.location, func(t *testing.T) {
+			got := Normalize(tt.location)
+			if got.City != tt.city || got.State != tt.state || got.Tier != tt.tier {
+				t.Errorf("Normalize(%q) = %q, %q, tier %d, want %q, %q, tier %d",
+					tt.location, got.City, got.State, got.Tier, tt.city, tt.state, tt.tier)
+			}
+			if (got.State != "") != (got.Lat != 0 || got.Lon != 0) {
+				t.Errorf("Normalize(%q) placed at %v, %v for state %q", tt.location, got.Lat, got.Lon, got.State)
+			}
+		})
+	}
+}
+
+func TestLookupState(t *testing.T) {
+	tests := []struct {
+		name  string
+		state string
+		found bool
+	}{
+		{"Karnataka", "Karnataka", true},
+		{"orissa", "Odisha", true},
+		{"TN", "Tamil Nadu", true},
+		{"Atlantis", "", false},
+	}
+
+	for _, tt := range tests {
+		state, found := LookupState(tt.name)
+		if found != tt.found || state.Name != tt.state {
+			t.Errorf("LookupState(%q) = %q, %v, want %q, %v", tt.name, state.Name, found, tt.state, tt.found)
+		}
+	}
+}
+
+func TestTierName(t *testing.T) {
+	for tier, want := range map[int]string{0: "Unknown", 1: "Tier 1", 2: "Tier 2", 3: "Tier 3", 7: "Unknown"} {
+		if got := TierName(tier); got != want {
+			t.Errorf("TierName(%d) = %q, want %q", tier, got, want)
+		}
+	}
+}
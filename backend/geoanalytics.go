@@ -0,0 +1,172 @@
+package main
+
+import (
+	"net/http"
+	"sort"
+
+	"github.com/gin-gonic/gin"
+	"github.com/your-username/shark-tank-analytics/geo"
+	"github.com/your-username/shark-tank-analytics/models"
+)
+
+// Metrics reported for each place
+const geoMetrics = "count,deals,deal_rate,total_capital,median_valuation"
+
+// getGeoAnalytics aggregates deals by state, city or city tier, e.g.
+// /api/analytics/geo?level=city_tier&season=2. Deals can be filtered by any
+// analytics dimension. Cities are grouped within their state, so same-named
+// cities in different states stay apart.
+func getGeoAnalytics(c *gin.Context) {
+	groups, level, ok := geoGroups(c, "state")
+	if !ok {
+		return
+	}
+
+	metrics, _ := analyticsMetricsFor(geoMetrics)
+	places := make([]gin.H, 0, len(groups))
+	grouped := make([][]models.Deal, 0, len(groups))
+	for _, group := range groups {
+		row := computeMetrics(group.Deals, metrics)
+		row[level] = group.Name
+		if level == "city" {
+			row["state"] = group.State
+		}
+		places = append(places, row)
+		grouped = append(grouped, group.Deals)
+	}
+	dimension, _ := findDimension(level)
+
+	c.JSON(http.StatusOK, gin.H{
+		"level":      level,
+		"places":     places,
+		"comparison": compareDealRates(grouped, []analyticsDimension{dimension}),
+	})
+}
+
+// getGeoJSON returns the state or city aggregates as a GeoJSON feature
+// collection of points for map rendering. Places that could not be located,
+// including the Unknown group of deals without a place, are left out.
+func getGeoJSON(c *gin.Context) {
+	groups, level, ok := geoGroups(c, "state")
+	if !ok {
+		return
+	}
+	if level == "city_tier" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "level must be state or city"})
+		return
+	}
+
+	metrics, _ := analyticsMetricsFor(geoMetrics)
+	features := make([]gin.H, 0, len(groups))
+	for _, group := range groups {
+		if group.Name == "Unknown" {
+			continue
+		}
+
+		var place geo.Place
+		if level == "city" {
+			place = geo.Normalize(group.Name + ", " + group.State)
+		} else if state, found := geo.LookupState(group.Name); found {
+			place = geo.Place{State: state.Name, Lat: state.Lat, Lon: state.Lon}
+		}
+		if place.Lat == 0 && place.Lon == 0 {
+			continue
+		}
+
+		properties := computeMetrics(group.Deals, metrics)
+		properties["name"] = group.Name
+		properties["state"] = place.State
+		if level == "city" {
+			properties["tier"] = geo.TierName(group.Deals[0].CityTier)
+		}
+
+		features = append(features, gin.H{
+			"type": "Feature",
+			"geometry": gin.H{
+				"type":        "Point",
+				"coordinates": []float64{place.Lon, place.Lat},
+			},
+			"properties": properties,
+		})
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"type":     "FeatureCollection",
+		"features": features,
+	})
+}
+
+// Helper functions
+
+// geoGroup is the deals of one place. State is only set for cities, which
+// are told apart by their state.
+type geoGroup struct {
+	Name  string
+	State string
+	Deals []models.Deal
+}
+
+// geoGroups loads the filtered deals and groups them by the requested level,
+// in natural order of the place names
+func geoGroups(c *gin.Context, defaultLevel string) ([]geoGroup, string, bool) {
+	level := c.DefaultQuery("level", defaultLevel)
+	if level == "tier" {
+		level = "city_tier"
+	}
+	if level != "state" && level != "city" && level != "city_tier" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "level must be state, city or city_tier"})
+		return nil, "", false
+	}
+
+	deals, err := loadDeals("")
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return nil, "", false
+	}
+	deals, _ = filterDeals(c, deals)
+
+	return groupByPlace(deals, level), level, true
+}
+
+// groupByPlace groups deals by a geo dimension. Cities are keyed by city and
+// state, with a single Unknown group for deals without a city.
+func groupByPlace(deals []models.Deal, level string) []geoGroup {
+	dimension, _ := findDimension(level)
+
+	index := make(map[[2]string]int)
+	groups := make([]geoGroup, 0)
+	for _, deal := range deals {
+		for _, name := range dimension.keys(deal) {
+			k := [2]string{name, ""}
+			if level == "city" && name != "Unknown" {
+				k[1] = labelOrUnknown(deal.State)
+			}
+			i, ok := index[k]
+			if !ok {
+				i = len(groups)
+				index[k] = i
+				groups = append(groups, geoGroup{Name: k[0], State: k[1]})
+			}
+			groups[i].Deals = append(groups[i].Deals, deal)
+		}
+	}
+
+	sort.Slice(groups, func(i, j int) bool {
+		if groups[i].Name != groups[j].Name {
+			return naturalLess(groups[i].Name, groups[j].Name)
+		}
+		return groups[i].State < groups[j].State
+	})
+	return groups
+}
+
+func sortedGroupNames(groups map[string][]models.Deal) []string {
+	names := make([]string, 0, len(groups))
+	for name := range groups {
+		names = append(names, name)
+	}
+	sort.Slice(names, func(i, j int) bool {
+		return naturalLess(names[i], names[j])
+	})
+	return names
+}
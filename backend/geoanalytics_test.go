@@ -0,0 +1,37 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/your-username/shark-tank-analytics/models"
+)
+
+func TestGroupByPlaceKeepsCitiesApartByState(t *testing.T) {
+	deals := []models.Deal{
+		{ID: 1, City: "Aurangabad", State: "Maharashtra"},
+		{ID: 2, City: "Aurangabad", State: "Bihar"},
+		{ID: 3, City: "Aurangabad", State: "Maharashtra"},
+		{ID: 4, State: "Goa"},
+		{ID: 5, State: "Kerala"},
+	}
+
+	groups := groupByPlace(deals, "city")
+	want := []struct {
+		name, state string
+		deals       int
+	}{
+		{"Aurangabad", "Bihar", 1},
+		{"Aurangabad", "Maharashtra", 2},
+		{"Unknown", "", 2},
+	}
+	if len(groups) != len(want) {
+		t.Fatalf("got %d groups, want %d", len(groups), len(want))
+	}
+	for i, w := range want {
+		g := groups[i]
+		if g.Name != w.name || g.State != w.state || len(g.Deals) != w.deals {
+			t.Errorf("group %d = %s, %s with %d deals, want %s, %s with %d",
+				i, g.Name, g.State, len(g.Deals), w.name, w.state, w.deals)
+		}
+	}
+}
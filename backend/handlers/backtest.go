@@ -0,0 +1,160 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"sort"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/your-username/shark-tank-analytics/ml"
+	"github.com/your-username/shark-tank-analytics/models"
+	"gorm.io/gorm"
+)
+
+// RunBacktest backtests a model version season by season, retraining with the
+// version's options, and stores the results under that version
+func RunBacktest(c *gin.Context) {
+	version := c.DefaultQuery("model_version", "")
+	if version == "" && predictor != nil {
+		version = predictor.Version
+	}
+	if version == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "model_version is required when no model is loaded"})
+		return
+	}
+	if !ml.ValidVersion(version) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid model_version"})
+		return
+	}
+
+	// Retrain the way the version was trained, so its results describe it
+	model, err := modelForVersion(version)
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		return
+	}
+	opts, err := model.TrainingOptions()
+	if err != nil {
+		c.JSON(http.StatusUnprocessableEntity, gin.H{"error": err.Error()})
+		return
+	}
+
+	var deals []models.Deal
+	if err := db.Find(&deals).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch deals"})
+		return
+	}
+
+	results, err := ml.Backtest(deals, opts)
+	if err != nil {
+		c.JSON(http.StatusUnprocessableEntity, gin.H{"error": err.Error()})
+		return
+	}
+
+	backtests, err := BacktestRecords(version, results)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to encode backtest"})
+		return
+	}
+
+	// A rerun replaces the earlier results of the same version, in one
+	// transaction so a failed insert keeps them
+	err = db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("model_version = ?", version).Delete(&models.Backtest{}).Error; err != nil {
+			return err
+		}
+		if len(backtests) == 0 {
+			return nil
+		}
+		return tx.Create(&backtests).Error
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to store backtest"})
+		return
+	}
+
+	c.JSON(http.StatusOK, backtests)
+}
+
+// GetBacktests returns stored backtests grouped by model version for comparison
+func GetBacktests(c *gin.Context) {
+	var backtests []models.Backtest
+
+	query := db.Model(&models.Backtest{})
+	if version := c.DefaultQuery("model_version", ""); version != "" {
+		query = query.Where("model_version = ?", version)
+	}
+
+	if err := query.Order("run_at, season").Find(&backtests).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch backtests"})
+		return
+	}
+
+	versions := make(map[string][]models.Backtest)
+	for _, backtest := range backtests {
+		versions[backtest.ModelVersion] = append(versions[backtest.ModelVersion], backtest)
+	}
+
+	result := make([]gin.H, 0, len(versions))
+	for version, seasons := range versions {
+		// Weight seasons by their number of deals so small seasons do not dominate
+		deals := 0
+		accuracy, brier := 0.0, 0.0
+		for _, season := range seasons {
+			deals += season.Deals
+			accuracy += season.Accuracy * float64(season.Deals)
+			brier += season.BrierScore * float64(season.Deals)
+		}
+		if deals > 0 {
+			accuracy /= float64(deals)
+			brier /= float64(deals)
+		}
+
+		result = append(result, gin.H{
+			"model_version": version,
+			"run_at":        seasons[0].RunAt,
+			"deals":         deals,
+			"accuracy":      accuracy,
+			"brier_score":   brier,
+			"seasons":       seasons,
+		})
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i]["run_at"].(time.Time).Before(result[j]["run_at"].(time.Time))
+	})
+
+	c.JSON(http.StatusOK, result)
+}
+
+// BacktestRecords converts backtest results into rows stamped with a model version
+func BacktestRecords(version string, results []ml.SeasonBacktest) ([]models.Backtest, error) {
+	runAt := time.Now().UTC()
+	records := make([]models.Backtest, 0, len(results))
+
+	for _, result := range results {
+		calibration, err := json.Marshal(result.Calibration)
+		if err != nil {
+			return nil, err
+		}
+		industries, err := json.Marshal(result.Industries)
+		if err != nil {
+			return nil, err
+		}
+
+		records = append(records, models.Backtest{
+			ModelVersion: version,
+			RunAt:        runAt,
+			Season:       result.Season,
+			TrainingSize: result.TrainingSize,
+			Deals:        result.Deals,
+			Accuracy:     result.Accuracy,
+			BrierScore:   result.BrierScore,
+			AUC:          result.AUC,
+			Calibration:  calibration,
+			Industries:   industries,
+		})
+	}
+
+	return records, nil
+}
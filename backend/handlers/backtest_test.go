@@ -0,0 +1,105 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/your-username/shark-tank-analytics/ml"
+	"github.com/your-username/shark-tank-analytics/models"
+)
+
+// seedBacktestDeals stores two seasons of pitches, enough to train on the
+// first and score the second
+func seedBacktestDeals(t *testing.T) {
+	t.Helper()
+
+	var deals []models.Deal
+	for season := 1; season <= 2; season++ {
+		for i := 0; i < 16; i++ {
+			deal := models.Deal{
+				Season:      season,
+				Episode:     i/4 + 1,
+				StartupName: fmt.Sprintf("Startup %d-%d", season, i),
+				Industry:    []string{"Food", "Tech"}[i%2],
+				AskAmount:   float64(1+i%5) * 1e6,
+				AskEquity:   float64(2 + i%8),
+				TeamSize:    5 + i,
+			}
+			if i%3 != 0 {
+				deal.SuccessStatus = "funded"
+				deal.DealAmount = deal.AskAmount
+				deal.DealEquity = deal.AskEquity + 2
+			}
+			deals = append(deals, deal)
+		}
+	}
+	if err := db.Create(&deals).Error; err != nil {
+		t.Fatal(err)
+	}
+}
+
+func useModel(t *testing.T, model *ml.Model) {
+	t.Helper()
+	previous := predictor
+	predictor = model
+	t.Cleanup(func() { predictor = previous })
+}
+
+func TestRunBacktestReplacesResults(t *testing.T) {
+	setupTestDB(t)
+	seedBacktestDeals(t)
+	useModel(t, &ml.Model{Version: "v1", Options: ml.DefaultOptions})
+
+	old := models.Backtest{ModelVersion: "v1", Season: 9, Deals: 1}
+	if err := db.Create(&old).Error; err != nil {
+		t.Fatal(err)
+	}
+
+	rec := serve(t, RunBacktest, http.MethodPost, "/backtests", "/backtests", nil)
+	expectStatus(t, rec, http.StatusOK)
+
+	var stored []models.Backtest
+	if err := db.Where("model_version = ?", "v1").Find(&stored).Error; err != nil {
+		t.Fatal(err)
+	}
+	if len(stored) != 1 || stored[0].Season != 2 || stored[0].Deals != 16 {
+		t.Fatalf("stored backtests = %+v, want season 2 with 16 deals", stored)
+	}
+}
+
+func TestRunBacktestKeepsResultsWhenStoringFails(t *testing.T) {
+	setupTestDB(t)
+	seedBacktestDeals(t)
+	useModel(t, &ml.Model{Version: "v1", Options: ml.DefaultOptions})
+
+	old := models.Backtest{ModelVersion: "v1", Season: 9, Deals: 1}
+	if err := db.Create(&old).Error; err != nil {
+		t.Fatal(err)
+	}
+	err := db.Exec(`CREATE TRIGGER reject_backtests BEFORE INSERT ON backtests
+		BEGIN SELECT RAISE(ABORT, 'rejected'); END`).Error
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	rec := serve(t, RunBacktest, http.MethodPost, "/backtests", "/backtests", nil)
+	expectStatus(t, rec, http.StatusInternalServerError)
+
+	var stored []models.Backtest
+	if err := db.Where("model_version = ?", "v1").Find(&stored).Error; err != nil {
+		t.Fatal(err)
+	}
+	if len(stored) != 1 || stored[0].Season != 9 {
+		t.Fatalf("stored backtests = %+v, want the earlier run kept", stored)
+	}
+}
+
+func TestRunBacktestRejectsUnsafeVersions(t *testing.T) {
+	setupTestDB(t)
+
+	for _, version := range []string{"../../etc/passwd", "v1/../v2", "a..b", "v%201"} {
+		rec := serve(t, RunBacktest, http.MethodPost, "/backtests", "/backtests?model_version="+version, nil)
+		expectStatus(t, rec, http.StatusBadRequest)
+	}
+}
@@ -0,0 +1,178 @@
+package handlers
+
+import (
+	"net/http"
+	"sort"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/your-username/shark-tank-analytics/ml"
+	"github.com/your-username/shark-tank-analytics/models"
+	"github.com/your-username/shark-tank-analytics/stats"
+)
+
+// GetBiddingWars returns every contested pitch along with head-to-head records between sharks
+func GetBiddingWars(c *gin.Context) {
+	var deals []models.Deal
+
+	// Get query parameters for filtering
+	season := c.DefaultQuery("season", "")
+	industry := c.DefaultQuery("industry", "")
+
+	// Build query based on filters
+	query := db.Model(&models.Deal{})
+
+	if season != "" {
+		if seasonNum, err := strconv.Atoi(season); err == nil {
+			query = query.Where("season = ?", seasonNum)
+		}
+	}
+
+	if industry != "" {
+		query = query.Where("industry = ?", industry)
+	}
+
+	// Execute query
+	if err := query.Order("season, episode, id").Find(&deals).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch deals"})
+		return
+	}
+
+	contests := make([]gin.H, 0)
+	matrix := make(map[string]map[string]int)
+	records := make(map[string]*biddingRecord)
+
+	for _, deal := range deals {
+		winners, losers, ok := splitContest(deal)
+		if !ok {
+			continue
+		}
+
+		// Every winner beat every shark that was interested but did not invest
+		for _, winner := range winners {
+			recordFor(records, winner).Wins++
+			if matrix[winner] == nil {
+				matrix[winner] = make(map[string]int)
+			}
+			for _, loser := range losers {
+				matrix[winner][loser]++
+			}
+		}
+		for _, loser := range losers {
+			recordFor(records, loser).Losses++
+		}
+
+		askValuation := ml.AskValuation(deal)
+		dealValuation := impliedValuation(deal.DealAmount, deal.DealEquity)
+
+		contests = append(contests, gin.H{
+			"deal_id":           deal.ID,
+			"season":            deal.Season,
+			"episode":           deal.Episode,
+			"startup_name":      deal.StartupName,
+			"industry":          deal.Industry,
+			"interested_sharks": cleanSharkNames(deal.InterestedSharks),
+			"winners":           winners,
+			"losers":            losers,
+			"ask": gin.H{
+				"amount":    deal.AskAmount,
+				"equity":    deal.AskEquity,
+				"valuation": askValuation,
+			},
+			"final": gin.H{
+				"amount":    deal.DealAmount,
+				"equity":    deal.DealEquity,
+				"debt":      deal.DealDebt,
+				"valuation": dealValuation,
+			},
+			"equity_delta":      deal.DealEquity - deal.AskEquity,
+			"valuation_haircut": valuationHaircut(askValuation, dealValuation),
+		})
+	}
+
+	sharks := make([]gin.H, 0, len(records))
+	for name, record := range records {
+		contested := record.Wins + record.Losses
+		sharks = append(sharks, gin.H{
+			"name":              name,
+			"contested":         contested,
+			"wins":              record.Wins,
+			"losses":            record.Losses,
+			"win_rate":          float64(record.Wins) / float64(contested),
+			"win_rate_interval": stats.NewRate(record.Wins, contested),
+		})
+	}
+	sort.Slice(sharks, func(i, j int) bool {
+		return sharks[i]["name"].(string) < sharks[j]["name"].(string)
+	})
+
+	c.JSON(http.StatusOK, gin.H{
+		"total_contested": len(contests),
+		"contests":        contests,
+		"sharks":          sharks,
+		"head_to_head":    headToHead(matrix),
+	})
+}
+
+// Helper functions
+
+type biddingRecord struct {
+	Wins   int
+	Losses int
+}
+
+func recordFor(records map[string]*biddingRecord, shark string) *biddingRecord {
+	if records[shark] == nil {
+		records[shark] = &biddingRecord{}
+	}
+	return records[shark]
+}
+
+// splitContest reports whether a deal was contested, i.e. at least two sharks were
+// interested and only some of them ended up investing
+func splitContest(deal models.Deal) (winners, losers []string, ok bool) {
+	interested := cleanSharkNames(deal.InterestedSharks)
+	invested := make(map[string]bool)
+	for _, shark := range cleanSharkNames(deal.InvestedSharks) {
+		invested[shark] = true
+	}
+
+	if len(interested) < 2 || len(invested) == 0 {
+		return nil, nil, false
+	}
+
+	for _, shark := range interested {
+		if invested[shark] {
+			winners = append(winners, shark)
+		} else {
+			losers = append(losers, shark)
+		}
+	}
+
+	return winners, losers, len(winners) > 0 && len(losers) > 0
+}
+
+// headToHead turns the raw win counts into symmetric pairwise win/loss entries
+func headToHead(matrix map[string]map[string]int) map[string]map[string]gin.H {
+	result := make(map[string]map[string]gin.H)
+	for winner, opponents := range matrix {
+		for loser, wins := range opponents {
+			if result[winner] == nil {
+				result[winner] = make(map[string]gin.H)
+			}
+			if result[loser] == nil {
+				result[loser] = make(map[string]gin.H)
+			}
+			result[winner][loser] = gin.H{"wins": wins, "losses": matrix[loser][winner]}
+			result[loser][winner] = gin.H{"wins": matrix[loser][winner], "losses": wins}
+		}
+	}
+	return result
+}
+
+func valuationHaircut(ask, final float64) float64 {
+	if ask <= 0 || final <= 0 {
+		return 0
+	}
+	return 1 - final/ask
+}
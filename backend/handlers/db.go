@@ -0,0 +1,26 @@
+package handlers
+
+import (
+	"database/sql"
+
+	"github.com/glebarez/sqlite"
+	"gorm.io/gorm"
+)
+
+// db is the gorm handle the handlers query, set up by Connect
+var db *gorm.DB
+
+// Connect opens gorm on the server's existing SQLite connection, so handlers
+// and the database/sql code in main share one database and one pool. The
+// dialector is the pure Go one built on modernc.org/sqlite, the driver main
+// already uses, so the build does not need cgo. The tables are created by
+// main, not migrated here.
+func Connect(conn *sql.DB) error {
+	gormDB, err := gorm.Open(&sqlite.Dialector{Conn: conn}, &gorm.Config{})
+	if err != nil {
+		return err
+	}
+
+	db = gormDB
+	return nil
+}
@@ -104,31 +104,79 @@ func GetDealAnalytics(c *gin.Context) {
 	c.JSON(http.StatusOK, analytics)
 }
 
-// GetDealPredictions returns ML predictions for deals
+// GetDealPredictions returns ML predictions for a pitch described by query parameters
 func GetDealPredictions(c *gin.Context) {
-	industry := c.DefaultQuery("industry", "")
+	if predictor == nil {
+		c.JSON(http.StatusServiceUnavailable, gin.H{"error": "No prediction model loaded"})
+		return
+	}
+	
+	pitch := pitchFromQuery(c)
+	
+	var deals []models.Deal
+	if err := db.Find(&deals).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch deals"})
+		return
+	}
+	
+	var sharks []models.Shark
+	db.Find(&sharks)
+	
+	recommendedSharks := make([]string, 0, 3)
+	for _, recommendation := range recommendSharks(pitch, "", deals, sharks) {
+		if len(recommendedSharks) == 3 {
+			break
+		}
+		recommendedSharks = append(recommendedSharks, recommendation.Name)
+	}
+	
+	prediction := predictor.Predict(pitch)
 	
-	// Example prediction logic (replace with actual ML model)
 	predictions := []gin.H{
 		{
-			"industry":            industry,
-			"success_probability": 0.75,
-			"predicted_valuation": 50000000,
-			"risk_score":         3,
-			"growth_potential":   85,
-			"recommended_sharks": []string{"Ashneer Grover", "Namita Thapar"},
-			"market_insights": gin.H{
-				"market_size":     "₹12,000Cr",
-				"growth_rate":     25,
-				"competition":     "Medium",
-				"entry_barriers": "High",
-			},
+			"industry":             pitch.Industry,
+			"success_probability":  prediction.DealProbability,
+			"probability_band":     prediction.ProbabilityBand,
+			"expected_equity":      prediction.ExpectedEquity,
+			"equity_interval":      prediction.EquityInterval,
+			"expected_amount":      prediction.ExpectedAmount,
+			"amount_interval":      prediction.AmountInterval,
+			"predicted_valuation":  impliedValuation(prediction.ExpectedAmount, prediction.ExpectedEquity),
+			"recommended_sharks":   recommendedSharks,
+			"model_version":        prediction.ModelVersion,
 		},
 	}
 	
 	c.JSON(http.StatusOK, predictions)
 }
 
+// UpdatePostShowStatus records how a startup performed after the show
+func UpdatePostShowStatus(c *gin.Context) {
+	id := c.Param("id")
+	var deal models.Deal
+	
+	if err := db.First(&deal, id).Error; err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Deal not found"})
+		return
+	}
+	
+	var status models.PostShowStatus
+	if err := c.ShouldBindJSON(&status); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	
+	// Update with a single column skips the field's JSON serializer, so the
+	// column is selected and saved from the struct instead
+	deal.PostShowStatus = status
+	if err := db.Model(&deal).Select("post_show_status").Updates(&deal).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update deal"})
+		return
+	}
+	
+	c.JSON(http.StatusOK, deal)
+}
+
 // ImportDealsFromExcel imports deals from Excel file
 func ImportDealsFromExcel(c *gin.Context) {
 	file, err := c.FormFile("file")
@@ -141,4 +189,14 @@ func ImportDealsFromExcel(c *gin.Context) {
 	// Implementation depends on the Excel library being used
 	
 	c.JSON(http.StatusOK, gin.H{"message": "Deals imported successfully"})
-}
\ No newline at end of file
+}
+
+// Helper functions
+
+// impliedValuation returns the valuation implied by investing amount for equity percent
+func impliedValuation(amount, equity float64) float64 {
+	if equity <= 0 {
+		return 0
+	}
+	return amount * 100 / equity
+}
@@ -0,0 +1,47 @@
+package handlers
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/your-username/shark-tank-analytics/models"
+)
+
+func TestUpdatePostShowStatus(t *testing.T) {
+	setupTestDB(t)
+
+	deal := models.Deal{StartupName: "Skippi", Season: 1, Episode: 3}
+	if err := db.Create(&deal).Error; err != nil {
+		t.Fatal(err)
+	}
+
+	status := models.PostShowStatus{
+		RevenueGrowth:   1.5,
+		MarketExpansion: []string{"UAE"},
+		FundingRounds: []models.FundingRound{
+			{Round: "Series A", Amount: 5e7, Date: time.Date(2023, 4, 1, 0, 0, 0, 0, time.UTC)},
+		},
+	}
+	rec := serve(t, UpdatePostShowStatus, http.MethodPut, "/deals/:id/post-show", "/deals/1/post-show", status)
+	expectStatus(t, rec, http.StatusOK)
+
+	var stored models.Deal
+	if err := db.First(&stored, deal.ID).Error; err != nil {
+		t.Fatal(err)
+	}
+	got := stored.PostShowStatus
+	if got.RevenueGrowth != 1.5 || len(got.MarketExpansion) != 1 || len(got.FundingRounds) != 1 {
+		t.Fatalf("stored post show status = %+v, want %+v", got, status)
+	}
+	if got.FundingRounds[0].Round != "Series A" || !got.FundingRounds[0].Date.Equal(status.FundingRounds[0].Date) {
+		t.Errorf("stored funding round = %+v, want %+v", got.FundingRounds[0], status.FundingRounds[0])
+	}
+}
+
+func TestUpdatePostShowStatusMissingDeal(t *testing.T) {
+	setupTestDB(t)
+
+	rec := serve(t, UpdatePostShowStatus, http.MethodPut, "/deals/:id/post-show", "/deals/7/post-show", models.PostShowStatus{})
+	expectStatus(t, rec, http.StatusNotFound)
+}
@@ -0,0 +1,246 @@
+package handlers
+
+import (
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/your-username/shark-tank-analytics/ml"
+	"github.com/your-username/shark-tank-analytics/models"
+)
+
+// GetEpisode returns the pitches of an episode in air order, the sharks on the
+// panel and the episode totals. Pitches air in the order they were imported.
+func GetEpisode(c *gin.Context) {
+	season, episodeNumber, ok := episodeParams(c)
+	if !ok {
+		return
+	}
+
+	var deals []models.Deal
+	if err := db.Where("season = ? AND episode = ?", season, episodeNumber).Order("id").Find(&deals).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch deals"})
+		return
+	}
+
+	var episode models.Episode
+	hasEpisode := db.Where("season = ? AND episode = ?", season, episodeNumber).First(&episode).Error == nil
+	if !hasEpisode && len(deals) == 0 {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Episode not found"})
+		return
+	}
+	if !hasEpisode {
+		episode = models.Episode{Season: season, Episode: episodeNumber}
+	}
+
+	var appearances []models.SharkAppearance
+	db.Where("season = ? AND episode = ?", season, episodeNumber).Order("shark_name").Find(&appearances)
+
+	pitches := make([]gin.H, 0, len(deals))
+	for i, deal := range deals {
+		outcome := "no_deal"
+		if ml.Funded(deal) {
+			outcome = "deal"
+		}
+		pitches = append(pitches, gin.H{
+			"position":          i + 1,
+			"deal_id":           deal.ID,
+			"startup_name":      deal.StartupName,
+			"industry":          deal.Industry,
+			"outcome":           outcome,
+			"interested_sharks": cleanSharkNames(deal.InterestedSharks),
+			"invested_sharks":   cleanSharkNames(deal.InvestedSharks),
+			"ask": gin.H{
+				"amount":    deal.AskAmount,
+				"equity":    deal.AskEquity,
+				"valuation": ml.AskValuation(deal),
+			},
+			"final": gin.H{
+				"amount":    deal.DealAmount,
+				"equity":    deal.DealEquity,
+				"debt":      deal.DealDebt,
+				"valuation": impliedValuation(deal.DealAmount, deal.DealEquity),
+			},
+		})
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"episode":         episode,
+		"sharks_present":  appearances,
+		"roster_recorded": len(appearances) > 0,
+		"pitches":         pitches,
+		"totals":          episodeTotals(deals),
+	})
+}
+
+// UpdateEpisode sets the title and air date of an episode
+func UpdateEpisode(c *gin.Context) {
+	season, episodeNumber, ok := episodeParams(c)
+	if !ok {
+		return
+	}
+
+	var input struct {
+		Title   string `json:"title"`
+		AirDate string `json:"air_date"`
+	}
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if input.AirDate != "" {
+		if _, err := time.Parse("2006-01-02", input.AirDate); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "air_date must be YYYY-MM-DD"})
+			return
+		}
+	}
+
+	var episode models.Episode
+	if err := db.Where("season = ? AND episode = ?", season, episodeNumber).First(&episode).Error; err != nil {
+		episode = models.Episode{Season: season, Episode: episodeNumber}
+	}
+	episode.Title = strings.TrimSpace(input.Title)
+	episode.AirDate = input.AirDate
+
+	if err := db.Save(&episode).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update episode"})
+		return
+	}
+
+	c.JSON(http.StatusOK, episode)
+}
+
+// GetTimeline returns every episode in air order with its headline deal.
+// on_this_day=true keeps the episodes aired on today's month and day in any
+// year, date=MM-DD does the same for another day.
+func GetTimeline(c *gin.Context) {
+	day := c.DefaultQuery("date", "")
+	if day == "" && c.DefaultQuery("on_this_day", "") == "true" {
+		day = time.Now().Format("01-02")
+	}
+	if day != "" {
+		if _, err := time.Parse("01-02", day); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "date must be MM-DD"})
+			return
+		}
+	}
+
+	query := db.Model(&models.Episode{})
+	if season := c.DefaultQuery("season", ""); season != "" {
+		if seasonNum, err := strconv.Atoi(season); err == nil {
+			query = query.Where("season = ?", seasonNum)
+		}
+	}
+
+	var episodes []models.Episode
+	if err := query.Find(&episodes).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch episodes"})
+		return
+	}
+
+	var deals []models.Deal
+	if err := db.Order("id").Find(&deals).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch deals"})
+		return
+	}
+	byEpisode := make(map[[2]int][]models.Deal)
+	for _, deal := range deals {
+		key := [2]int{deal.Season, deal.Episode}
+		byEpisode[key] = append(byEpisode[key], deal)
+	}
+
+	// Seasons and episodes are numbered in air order, which also orders
+	// episodes whose air date is unknown
+	sort.Slice(episodes, func(i, j int) bool {
+		if episodes[i].Season != episodes[j].Season {
+			return episodes[i].Season < episodes[j].Season
+		}
+		return episodes[i].Episode < episodes[j].Episode
+	})
+
+	now := time.Now()
+	timeline := make([]gin.H, 0, len(episodes))
+	for _, episode := range episodes {
+		if day != "" && (len(episode.AirDate) != len("2006-01-02") || episode.AirDate[5:] != day) {
+			continue
+		}
+
+		episodeDeals := byEpisode[[2]int{episode.Season, episode.Episode}]
+		entry := gin.H{
+			"season":   episode.Season,
+			"episode":  episode.Episode,
+			"title":    episode.Title,
+			"air_date": episode.AirDate,
+			"totals":   episodeTotals(episodeDeals),
+			"headline": headlineDeal(episodeDeals),
+		}
+		if aired, err := time.Parse("2006-01-02", episode.AirDate); err == nil {
+			entry["years_ago"] = now.Year() - aired.Year()
+		}
+		timeline = append(timeline, entry)
+	}
+
+	c.JSON(http.StatusOK, timeline)
+}
+
+// Helper functions
+
+func episodeParams(c *gin.Context) (season, episode int, ok bool) {
+	season, err := strconv.Atoi(c.Param("n"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid season"})
+		return 0, 0, false
+	}
+	episode, err = strconv.Atoi(c.Param("e"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid episode"})
+		return 0, 0, false
+	}
+	return season, episode, true
+}
+
+func episodeTotals(deals []models.Deal) gin.H {
+	funded, asked, invested := 0, 0.0, 0.0
+	for _, deal := range deals {
+		asked += deal.AskAmount
+		if ml.Funded(deal) {
+			funded++
+			invested += deal.DealAmount
+		}
+	}
+
+	totals := gin.H{
+		"pitches":          len(deals),
+		"deals":            funded,
+		"deal_rate":        nil,
+		"total_asked":      asked,
+		"total_investment": invested,
+	}
+	if len(deals) > 0 {
+		totals["deal_rate"] = float64(funded) / float64(len(deals))
+	}
+	return totals
+}
+
+// headlineDeal is the largest deal of an episode, nil when nothing was funded
+func headlineDeal(deals []models.Deal) gin.H {
+	var best *models.Deal
+	for i := range deals {
+		if ml.Funded(deals[i]) && (best == nil || deals[i].DealAmount > best.DealAmount) {
+			best = &deals[i]
+		}
+	}
+	if best == nil {
+		return nil
+	}
+	return gin.H{
+		"deal_id":         best.ID,
+		"startup_name":    best.StartupName,
+		"deal_amount":     best.DealAmount,
+		"deal_equity":     best.DealEquity,
+		"invested_sharks": cleanSharkNames(best.InvestedSharks),
+	}
+}
@@ -0,0 +1,97 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/your-username/shark-tank-analytics/models"
+)
+
+func TestUpdateEpisode(t *testing.T) {
+	setupTestDB(t)
+
+	route := "/seasons/:n/episodes/:e"
+	rec := serve(t, UpdateEpisode, http.MethodPut, route, "/seasons/1/episodes/2", gin.H{"title": " Ice-pops and ayurveda ", "air_date": "2021-12-21"})
+	expectStatus(t, rec, http.StatusOK)
+
+	var episode models.Episode
+	if err := db.Where("season = ? AND episode = ?", 1, 2).First(&episode).Error; err != nil {
+		t.Fatalf("episode not created: %v", err)
+	}
+	if episode.Title != "Ice-pops and ayurveda" || episode.AirDate != "2021-12-21" {
+		t.Errorf("stored episode = %+v, want the trimmed title and air date", episode)
+	}
+
+	// Updating again changes the same row
+	rec = serve(t, UpdateEpisode, http.MethodPut, route, "/seasons/1/episodes/2", gin.H{"title": "Episode 2"})
+	expectStatus(t, rec, http.StatusOK)
+
+	var episodes []models.Episode
+	db.Find(&episodes)
+	if len(episodes) != 1 || episodes[0].ID != episode.ID || episodes[0].Title != "Episode 2" || episodes[0].AirDate != "" {
+		t.Errorf("stored episodes = %+v, want the one episode retitled with its date cleared", episodes)
+	}
+
+	tests := []struct {
+		name   string
+		target string
+		body   gin.H
+	}{
+		{"invalid date", "/seasons/1/episodes/2", gin.H{"air_date": "21/12/2021"}},
+		{"invalid season", "/seasons/one/episodes/2", gin.H{"title": "x"}},
+		{"invalid episode", "/seasons/1/episodes/two", gin.H{"title": "x"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			expectStatus(t, serve(t, UpdateEpisode, http.MethodPut, route, tt.target, tt.body), http.StatusBadRequest)
+		})
+	}
+}
+
+func TestGetEpisode(t *testing.T) {
+	setupTestDB(t)
+
+	deals := []models.Deal{
+		{StartupName: "Skippi", Season: 1, Episode: 2, AskAmount: 4.5e6, DealAmount: 1e7, DealEquity: 15},
+		{StartupName: "Heart Up My Sleeves", Season: 1, Episode: 2, AskAmount: 2.5e6},
+		{StartupName: "Elsewhere", Season: 1, Episode: 3},
+	}
+	if err := db.Create(&deals).Error; err != nil {
+		t.Fatal(err)
+	}
+	expectStatus(t, serve(t, UpdateEpisode, http.MethodPut, "/seasons/:n/episodes/:e", "/seasons/1/episodes/2", gin.H{"air_date": "2021-12-21"}), http.StatusOK)
+
+	route := "/seasons/:n/episodes/:e"
+	rec := serve(t, GetEpisode, http.MethodGet, route, "/seasons/1/episodes/2", nil)
+	expectStatus(t, rec, http.StatusOK)
+
+	var result struct {
+		Episode models.Episode `json:"episode"`
+		Pitches []struct {
+			Position    int    `json:"position"`
+			StartupName string `json:"startup_name"`
+			Outcome     string `json:"outcome"`
+		} `json:"pitches"`
+		Totals struct {
+			Pitches  int     `json:"pitches"`
+			Deals    int     `json:"deals"`
+			DealRate float64 `json:"deal_rate"`
+		} `json:"totals"`
+	}
+	if err := json.Unmarshal(rec.Body.Bytes(), &result); err != nil {
+		t.Fatal(err)
+	}
+	if result.Episode.AirDate != "2021-12-21" || len(result.Pitches) != 2 {
+		t.Fatalf("episode = %+v with %d pitches, want the dated episode with 2", result.Episode, len(result.Pitches))
+	}
+	if result.Pitches[0].StartupName != "Skippi" || result.Pitches[0].Outcome != "deal" || result.Pitches[1].Outcome != "no_deal" {
+		t.Errorf("pitches = %+v, want Skippi's deal first", result.Pitches)
+	}
+	if result.Totals.Pitches != 2 || result.Totals.Deals != 1 || result.Totals.DealRate != 0.5 {
+		t.Errorf("totals = %+v, want 1 deal in 2 pitches", result.Totals)
+	}
+
+	expectStatus(t, serve(t, GetEpisode, http.MethodGet, route, "/seasons/1/episodes/9", nil), http.StatusNotFound)
+}
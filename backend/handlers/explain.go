@@ -0,0 +1,138 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/your-username/shark-tank-analytics/ml"
+	"github.com/your-username/shark-tank-analytics/models"
+)
+
+// Number of reasons and similar deals returned by default
+const (
+	explainReasons      = 5
+	explainSimilarDeals = 5
+)
+
+// ExplainPrediction returns the feature attributions behind a stored prediction
+// together with the most similar historical deals
+func ExplainPrediction(c *gin.Context) {
+	id := c.Param("id")
+	var prediction models.Prediction
+
+	if err := db.First(&prediction, id).Error; err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Prediction not found"})
+		return
+	}
+
+	// Explain with the model that made the prediction, not the one served now
+	model, err := modelForVersion(prediction.ModelVersion)
+	if err != nil {
+		c.JSON(http.StatusServiceUnavailable, gin.H{"error": err.Error()})
+		return
+	}
+
+	var deal models.Deal
+	if err := db.First(&deal, prediction.DealID).Error; err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Deal not found"})
+		return
+	}
+
+	k, err := strconv.Atoi(c.DefaultQuery("k", strconv.Itoa(explainSimilarDeals)))
+	if err != nil || k < 1 {
+		k = explainSimilarDeals
+	}
+
+	var deals []models.Deal
+	if err := db.Find(&deals).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch deals"})
+		return
+	}
+
+	// Only the ask side is known when a pitch is scored
+	pitch := deal
+	pitch.DealAmount = 0
+	pitch.DealEquity = 0
+	pitch.InvestedSharks = nil
+
+	similar := make([]gin.H, 0, k)
+	for _, neighbor := range nearestDeals(pitch, "", deals, k) {
+		similar = append(similar, gin.H{
+			"deal_id":      neighbor.deal.ID,
+			"startup_name": neighbor.deal.StartupName,
+			"industry":     neighbor.deal.Industry,
+			"season":       neighbor.deal.Season,
+			"episode":      neighbor.deal.Episode,
+			"ask_amount":   neighbor.deal.AskAmount,
+			"ask_equity":   neighbor.deal.AskEquity,
+			"got_deal":     ml.Funded(neighbor.deal),
+			"deal_amount":  neighbor.deal.DealAmount,
+			"deal_equity":  neighbor.deal.DealEquity,
+			"similarity":   neighbor.similarity,
+		})
+	}
+
+	attributions := model.Contributions(pitch)
+
+	reasons := make([]string, 0, explainReasons)
+	for _, attribution := range attributions {
+		if len(reasons) == explainReasons {
+			break
+		}
+		reasons = append(reasons, describeAttribution(attribution))
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"prediction":    prediction,
+		"deal_id":       deal.ID,
+		"startup_name":  deal.StartupName,
+		"model_version": model.Version,
+		"baseline":      model.Deal.Bias,
+		"attributions":  attributions,
+		"reasons":       reasons,
+		"similar_deals": similar,
+	})
+}
+
+// Helper functions
+
+var featureLabels = map[string]string{
+	"log_ask_amount":    "ask amount",
+	"ask_equity":        "equity offered",
+	"log_ask_valuation": "asked valuation",
+	"log_revenue":       "current revenue",
+	"has_revenue":       "having revenue",
+	"profit_margin":     "profit margin",
+	"log_team_size":     "team size",
+	"has_patent":        "patent status",
+}
+
+// describeAttribution turns a feature attribution into a sentence for the reasons list
+func describeAttribution(a ml.Contribution) string {
+	direction := "raised"
+	if a.Contribution < 0 {
+		direction = "lowered"
+	}
+
+	// Category features also contribute when the pitch is not in the category
+	for _, prefix := range []string{"industry=", "location="} {
+		if !strings.HasPrefix(a.Feature, prefix) {
+			continue
+		}
+		kind := strings.TrimSuffix(prefix, "=")
+		value := strings.TrimPrefix(a.Feature, prefix)
+		if a.Value == 0 {
+			return fmt.Sprintf("Not having %s %q %s the deal likelihood", kind, value, direction)
+		}
+		return fmt.Sprintf("Having %s %q %s the deal likelihood", kind, value, direction)
+	}
+
+	label := featureLabels[a.Feature]
+	if label == "" {
+		label = a.Feature
+	}
+	return fmt.Sprintf("The %s %s the deal likelihood", label, direction)
+}
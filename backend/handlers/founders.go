@@ -0,0 +1,95 @@
+package handlers
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/your-username/shark-tank-analytics/models"
+	"gorm.io/gorm"
+)
+
+// Allowed founder attribute values, empty meaning unknown
+var (
+	founderGenders  = []string{"", "male", "female", "other"}
+	founderAgeBands = []string{"", "under-25", "25-34", "35-44", "45+"}
+)
+
+// GetDealFounders returns the founders of a deal
+func GetDealFounders(c *gin.Context) {
+	id := c.Param("id")
+	var founders []models.Founder
+
+	if err := db.Where("deal_id = ?", id).Order("id").Find(&founders).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch founders"})
+		return
+	}
+
+	c.JSON(http.StatusOK, founders)
+}
+
+// SetDealFounders replaces the founders recorded for a deal
+func SetDealFounders(c *gin.Context) {
+	id := c.Param("id")
+	var deal models.Deal
+
+	if err := db.First(&deal, id).Error; err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Deal not found"})
+		return
+	}
+
+	var input []models.Founder
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	founders := make([]models.Founder, 0, len(input))
+	for _, founder := range input {
+		founder.Gender = strings.ToLower(strings.TrimSpace(founder.Gender))
+		founder.AgeBand = strings.ToLower(strings.TrimSpace(founder.AgeBand))
+		if !containsString(founderGenders, founder.Gender) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "gender must be male, female or other"})
+			return
+		}
+		if !containsString(founderAgeBands, founder.AgeBand) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "age_band must be under-25, 25-34, 35-44 or 45+"})
+			return
+		}
+		founders = append(founders, models.Founder{
+			DealID:        deal.ID,
+			Name:          strings.TrimSpace(founder.Name),
+			Gender:        founder.Gender,
+			AgeBand:       founder.AgeBand,
+			RepeatFounder: founder.RepeatFounder,
+		})
+	}
+
+	// Replace the founders in one transaction so a failed insert keeps the old ones
+	err := db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("deal_id = ?", deal.ID).Delete(&models.Founder{}).Error; err != nil {
+			return err
+		}
+		if len(founders) == 0 {
+			return nil
+		}
+		return tx.Create(&founders).Error
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update founders"})
+		return
+	}
+
+	c.JSON(http.StatusOK, founders)
+}
+
+// Helper functions
+
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
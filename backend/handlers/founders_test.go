@@ -0,0 +1,72 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/your-username/shark-tank-analytics/models"
+)
+
+func TestSetDealFounders(t *testing.T) {
+	setupTestDB(t)
+
+	deal := models.Deal{StartupName: "Bummer", Season: 1, Episode: 5}
+	if err := db.Create(&deal).Error; err != nil {
+		t.Fatal(err)
+	}
+	if err := db.Create(&models.Founder{DealID: deal.ID, Name: "Someone Old"}).Error; err != nil {
+		t.Fatal(err)
+	}
+
+	route := "/deals/:id/founders"
+	founders := []gin.H{
+		{"name": " Sreejith Moolayil ", "gender": "Male", "age_band": "25-34", "repeat_founder": true},
+		{"name": "Founder Two"},
+	}
+	rec := serve(t, SetDealFounders, http.MethodPut, route, "/deals/1/founders", founders)
+	expectStatus(t, rec, http.StatusOK)
+
+	var stored []models.Founder
+	db.Where("deal_id = ?", deal.ID).Order("id").Find(&stored)
+	if len(stored) != 2 {
+		t.Fatalf("stored founders = %+v, want the two sent replacing the old one", stored)
+	}
+	first := stored[0]
+	if first.Name != "Sreejith Moolayil" || first.Gender != "male" || first.AgeBand != "25-34" || !first.RepeatFounder {
+		t.Errorf("stored founder = %+v, want a normalized repeat founder", first)
+	}
+	if stored[1].Gender != "" || stored[1].AgeBand != "" {
+		t.Errorf("stored founder = %+v, want unknown gender and age", stored[1])
+	}
+
+	rec = serve(t, GetDealFounders, http.MethodGet, route, "/deals/1/founders", nil)
+	expectStatus(t, rec, http.StatusOK)
+	var listed []models.Founder
+	if err := json.Unmarshal(rec.Body.Bytes(), &listed); err != nil || len(listed) != 2 {
+		t.Errorf("listed founders = %+v (%v), want the 2 stored", listed, err)
+	}
+
+	tests := []struct {
+		name     string
+		target   string
+		founders []gin.H
+		status   int
+	}{
+		{"unknown gender", "/deals/1/founders", []gin.H{{"name": "x", "gender": "unknown"}}, http.StatusBadRequest},
+		{"unknown age band", "/deals/1/founders", []gin.H{{"name": "x", "age_band": "30s"}}, http.StatusBadRequest},
+		{"missing deal", "/deals/9/founders", []gin.H{{"name": "x"}}, http.StatusNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			expectStatus(t, serve(t, SetDealFounders, http.MethodPut, route, tt.target, tt.founders), tt.status)
+
+			var count int64
+			db.Model(&models.Founder{}).Count(&count)
+			if count != 2 {
+				t.Errorf("%d founders stored after a rejected update, want the 2 kept", count)
+			}
+		})
+	}
+}
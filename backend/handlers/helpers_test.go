@@ -0,0 +1,102 @@
+package handlers
+
+import (
+	"bytes"
+	"database/sql"
+	"encoding/json"
+	"mime/multipart"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/your-username/shark-tank-analytics/models"
+	"gorm.io/gorm/logger"
+)
+
+func init() {
+	gin.SetMode(gin.TestMode)
+}
+
+// setupTestDB connects the handlers to a fresh in-memory database holding the
+// tables the handlers write to
+func setupTestDB(t *testing.T) {
+	t.Helper()
+
+	conn, err := sql.Open("sqlite", ":memory:")
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Every connection to :memory: is a separate database
+	conn.SetMaxOpenConns(1)
+	t.Cleanup(func() { conn.Close() })
+
+	if err := Connect(conn); err != nil {
+		t.Fatal(err)
+	}
+	db.Logger = logger.Default.LogMode(logger.Silent)
+	err = db.AutoMigrate(
+		&models.Deal{}, &models.Founder{}, &models.Shark{}, &models.SharkAppearance{},
+		&models.Episode{}, &models.Season{}, &models.Prediction{}, &models.Backtest{},
+		&models.DealLabel{},
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+// serve runs a single request against handler mounted at route and returns the response
+func serve(t *testing.T, handler gin.HandlerFunc, method, route, target string, body interface{}) *httptest.ResponseRecorder {
+	t.Helper()
+
+	var payload bytes.Buffer
+	if body != nil {
+		if err := json.NewEncoder(&payload).Encode(body); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	router := gin.New()
+	router.Handle(method, route, handler)
+
+	req := httptest.NewRequest(method, target, &payload)
+	req.Header.Set("Content-Type", "application/json")
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+	return rec
+}
+
+// serveFile runs a single multipart request uploading content as the file
+// field against handler mounted at route and returns the response
+func serveFile(t *testing.T, handler gin.HandlerFunc, route, target, field, filename string, content []byte) *httptest.ResponseRecorder {
+	t.Helper()
+
+	var payload bytes.Buffer
+	form := multipart.NewWriter(&payload)
+	part, err := form.CreateFormFile(field, filename)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := part.Write(content); err != nil {
+		t.Fatal(err)
+	}
+	if err := form.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	router := gin.New()
+	router.POST(route, handler)
+
+	req := httptest.NewRequest("POST", target, &payload)
+	req.Header.Set("Content-Type", form.FormDataContentType())
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+	return rec
+}
+
+// expectStatus fails the test when the response does not have the wanted status
+func expectStatus(t *testing.T, rec *httptest.ResponseRecorder, want int) {
+	t.Helper()
+	if rec.Code != want {
+		t.Fatalf("status = %d, want %d: %s", rec.Code, want, rec.Body.String())
+	}
+}
@@ -0,0 +1,83 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/your-username/shark-tank-analytics/ml"
+	"github.com/your-username/shark-tank-analytics/models"
+)
+
+// categorizer is the pitch text classifier trained at startup, nil until then
+var categorizer *ml.Categorizer
+
+// LoadCategorizer trains the pitch text classifier used by the labeling endpoints
+func LoadCategorizer(deals []models.Deal) *ml.Categorizer {
+	categorizer = ml.TrainCategorizer(deals)
+	return categorizer
+}
+
+// GetDealLabels returns the classifier's suggested labels for each deal, only
+// the likely mislabeled ones with mislabeled=true
+func GetDealLabels(c *gin.Context) {
+	var labels []models.DealLabel
+
+	query := db.Model(&models.DealLabel{})
+	if c.DefaultQuery("mislabeled", "") == "true" {
+		query = query.Where("industry_mislabeled = ? OR category_mislabeled = ?", true, true)
+	}
+
+	if err := query.Order("deal_id").Find(&labels).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch deal labels"})
+		return
+	}
+
+	var deals []models.Deal
+	if err := db.Find(&deals).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch deals"})
+		return
+	}
+	dealsByID := make(map[uint]models.Deal, len(deals))
+	for _, deal := range deals {
+		dealsByID[deal.ID] = deal
+	}
+
+	result := make([]gin.H, 0, len(labels))
+	for _, label := range labels {
+		deal := dealsByID[label.DealID]
+		result = append(result, gin.H{
+			"deal_id":          label.DealID,
+			"startup_name":     deal.StartupName,
+			"industry":         deal.Industry,
+			"product_category": deal.ProductCategory,
+			"suggestion":       label,
+		})
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+// ClassifyPitch suggests an industry and product category for a pitch's text
+func ClassifyPitch(c *gin.Context) {
+	if categorizer == nil {
+		c.JSON(http.StatusServiceUnavailable, gin.H{"error": "Pitch classifier is not trained yet"})
+		return
+	}
+
+	var input struct {
+		StartupName      string `json:"startup_name"`
+		PitchDescription string `json:"pitch_description"`
+	}
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	pitch := models.Deal{StartupName: input.StartupName, PitchDescription: input.PitchDescription}
+	if ml.PitchText(pitch) == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "startup_name or pitch_description is required"})
+		return
+	}
+
+	c.JSON(http.StatusOK, categorizer.Suggest(pitch))
+}
@@ -0,0 +1,162 @@
+package handlers
+
+import (
+	"net/http"
+	"sort"
+
+	"github.com/gin-gonic/gin"
+	"github.com/your-username/shark-tank-analytics/models"
+)
+
+// GetSharkPortfolio returns the post-show performance of every startup a shark backed
+func GetSharkPortfolio(c *gin.Context) {
+	id := c.Param("id")
+	var shark models.Shark
+
+	if err := db.First(&shark, "id = ?", id).Error; err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Shark not found"})
+		return
+	}
+
+	deals, err := dealsWithShark(shark.Name)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch deals"})
+		return
+	}
+
+	holdings := make([]gin.H, 0, len(deals))
+	totalInvested := 0.0
+	totalPaperValue := 0.0
+	totalFollowOn := 0.0
+	withFollowOn := 0
+	marked := 0
+	revenueGrowth := 0.0
+	employeeGrowth := 0.0
+
+	for _, deal := range deals {
+		holding := valueHolding(deal)
+
+		totalInvested += holding.Invested
+		totalPaperValue += holding.PaperValue
+		totalFollowOn += holding.FollowOn
+		revenueGrowth += deal.PostShowStatus.RevenueGrowth
+		employeeGrowth += deal.PostShowStatus.EmployeeGrowth
+		if len(deal.PostShowStatus.FundingRounds) > 0 {
+			withFollowOn++
+		}
+		if holding.Marked {
+			marked++
+		}
+
+		holdings = append(holdings, gin.H{
+			"deal_id":          deal.ID,
+			"startup_name":     deal.StartupName,
+			"industry":         deal.Industry,
+			"season":           deal.Season,
+			"invested":         holding.Invested,
+			"entry_equity":     holding.EntryEquity,
+			"entry_valuation":  holding.EntryValuation,
+			"latest_valuation": holding.LatestValuation,
+			"markup_multiple":  holding.Markup,
+			"current_equity":   holding.CurrentEquity,
+			"paper_value":      holding.PaperValue,
+			"paper_return":     holding.Return(),
+			"follow_on_raised": holding.FollowOn,
+			"marked_up":        holding.Marked,
+			"post_show_status": deal.PostShowStatus,
+		})
+	}
+
+	// Best performers first
+	sort.SliceStable(holdings, func(i, j int) bool {
+		return holdings[i]["paper_return"].(float64) > holdings[j]["paper_return"].(float64)
+	})
+
+	summary := gin.H{
+		"companies":           len(deals),
+		"total_invested":      totalInvested,
+		"paper_value":         totalPaperValue,
+		"paper_return":        0.0,
+		"follow_on_raised":    totalFollowOn,
+		"with_follow_on":      withFollowOn,
+		"marked_companies":    marked,
+		"avg_revenue_growth":  0.0,
+		"avg_employee_growth": 0.0,
+	}
+	if totalInvested > 0 {
+		summary["paper_return"] = totalPaperValue / totalInvested
+	}
+	if len(deals) > 0 {
+		summary["avg_revenue_growth"] = revenueGrowth / float64(len(deals))
+		summary["avg_employee_growth"] = employeeGrowth / float64(len(deals))
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"shark":    gin.H{"id": shark.ID, "name": shark.Name},
+		"summary":  summary,
+		"holdings": holdings,
+	})
+}
+
+// Helper functions
+
+type holdingValue struct {
+	Invested        float64
+	EntryEquity     float64
+	EntryValuation  float64
+	LatestValuation float64
+	Markup          float64
+	CurrentEquity   float64
+	PaperValue      float64
+	FollowOn        float64
+	Marked          bool
+}
+
+// Return is the paper value per rupee invested, or zero when nothing was invested
+func (h holdingValue) Return() float64 {
+	if h.Invested <= 0 {
+		return 0
+	}
+	return h.PaperValue / h.Invested
+}
+
+// valueHolding estimates the current value of a shark's stake in a deal.
+//
+// The stake is the shark's even share of the deal equity, diluted by each later
+// round by amount / post-money valuation. The stake is marked at the most recent
+// disclosed post-money valuation; without one it is held at the entry valuation.
+func valueHolding(deal models.Deal) holdingValue {
+	invested, equity := sharkShare(deal)
+	holding := holdingValue{
+		Invested:       invested,
+		EntryEquity:    equity,
+		EntryValuation: impliedValuation(deal.DealAmount, deal.DealEquity),
+		CurrentEquity:  equity,
+		Markup:         1,
+	}
+	holding.LatestValuation = holding.EntryValuation
+
+	rounds := append([]models.FundingRound(nil), deal.PostShowStatus.FundingRounds...)
+	sort.SliceStable(rounds, func(i, j int) bool {
+		return rounds[i].Date.Before(rounds[j].Date)
+	})
+
+	for _, round := range rounds {
+		holding.FollowOn += round.Amount
+		if round.PostMoneyValuation <= 0 {
+			continue
+		}
+		if round.Amount < round.PostMoneyValuation {
+			holding.CurrentEquity *= 1 - round.Amount/round.PostMoneyValuation
+		}
+		holding.LatestValuation = round.PostMoneyValuation
+		holding.Marked = true
+	}
+
+	if holding.EntryValuation > 0 {
+		holding.Markup = holding.LatestValuation / holding.EntryValuation
+	}
+	holding.PaperValue = holding.CurrentEquity / 100 * holding.LatestValuation
+
+	return holding
+}
@@ -0,0 +1,85 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/your-username/shark-tank-analytics/models"
+	"github.com/your-username/shark-tank-analytics/risk"
+)
+
+// Number of sharks and features returned with a pitch score
+const (
+	predictTopSharks   = 5
+	predictTopFeatures = 5
+)
+
+// PredictPitch scores a hypothetical pitch described with the same fields as a deal
+func PredictPitch(c *gin.Context) {
+	if predictor == nil {
+		c.JSON(http.StatusServiceUnavailable, gin.H{"error": "No prediction model loaded"})
+		return
+	}
+
+	var pitch models.Deal
+	if err := c.ShouldBindJSON(&pitch); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if pitch.AskAmount <= 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "ask_amount must be positive"})
+		return
+	}
+	if pitch.AskEquity <= 0 || pitch.AskEquity > 100 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "ask_equity must be between 0 and 100"})
+		return
+	}
+
+	// Outcome fields are not inputs to a hypothetical pitch
+	pitch.ID = 0
+	pitch.DealAmount = 0
+	pitch.DealEquity = 0
+	pitch.DealDebt = 0
+	pitch.InvestedSharks = nil
+	pitch.InterestedSharks = nil
+	pitch.SuccessStatus = ""
+
+	var deals []models.Deal
+	if err := db.Find(&deals).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch deals"})
+		return
+	}
+
+	var sharks []models.Shark
+	db.Find(&sharks)
+
+	interested := recommendSharks(pitch, "", deals, sharks)
+	if len(interested) > predictTopSharks {
+		interested = interested[:predictTopSharks]
+	}
+
+	features := predictor.Contributions(pitch)
+	if len(features) > predictTopFeatures {
+		features = features[:predictTopFeatures]
+	}
+
+	prediction := predictor.Predict(pitch)
+
+	c.JSON(http.StatusOK, gin.H{
+		"deal_likelihood":      prediction.DealProbability,
+		"likelihood_band":      prediction.ProbabilityBand,
+		"counter_offer_equity": prediction.ExpectedEquity,
+		"equity_interval":      prediction.EquityInterval,
+		"expected_amount":      prediction.ExpectedAmount,
+		"amount_interval":      prediction.AmountInterval,
+		"ask_valuation":        prediction.AskValuation,
+		"counter_valuation":    prediction.CounterValuation,
+		"valuation_interval":   prediction.ValuationInterval,
+		"valuation_haircut":    prediction.ValuationHaircut,
+		"interested_sharks":    interested,
+		"top_features":         features,
+		"risk":                 risk.Score(pitch, RiskConfig),
+		"model_version":        prediction.ModelVersion,
+	})
+}
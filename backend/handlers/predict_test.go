@@ -0,0 +1,58 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/your-username/shark-tank-analytics/ml"
+	"github.com/your-username/shark-tank-analytics/models"
+	"github.com/your-username/shark-tank-analytics/risk"
+)
+
+func TestPredictPitch(t *testing.T) {
+	setupTestDB(t)
+	seedBacktestDeals(t)
+
+	var deals []models.Deal
+	if err := db.Find(&deals).Error; err != nil {
+		t.Fatal(err)
+	}
+	model, err := ml.Train(deals, ml.DefaultOptions)
+	if err != nil {
+		t.Fatal(err)
+	}
+	useModel(t, model)
+
+	pitch := gin.H{"ask_amount": 5e6, "ask_equity": 5, "founded_year": time.Now().Year() - 3}
+	rec := serve(t, PredictPitch, http.MethodPost, "/predict", "/predict", pitch)
+	expectStatus(t, rec, http.StatusOK)
+
+	var result struct {
+		DealLikelihood float64     `json:"deal_likelihood"`
+		LikelihoodBand ml.Interval `json:"likelihood_band"`
+		Risk           risk.Result `json:"risk"`
+	}
+	if err := json.Unmarshal(rec.Body.Bytes(), &result); err != nil {
+		t.Fatal(err)
+	}
+	if result.LikelihoodBand.Low > result.LikelihoodBand.High {
+		t.Errorf("likelihood band = %+v, want an ordered range", result.LikelihoodBand)
+	}
+
+	// A hypothetical pitch has no season, so it is aged as of this year
+	age := -1.0
+	for _, c := range result.Risk.Components {
+		if c.Name == "company_age" {
+			age = c.Value
+		}
+	}
+	if age != 3 {
+		t.Errorf("company_age = %v, want 3 years", age)
+	}
+
+	rec = serve(t, PredictPitch, http.MethodPost, "/predict", "/predict", gin.H{"ask_amount": 5e6, "ask_equity": 150})
+	expectStatus(t, rec, http.StatusBadRequest)
+}
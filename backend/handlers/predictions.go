@@ -0,0 +1,172 @@
+package handlers
+
+import (
+	"math"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/your-username/shark-tank-analytics/ml"
+	"github.com/your-username/shark-tank-analytics/models"
+)
+
+// GetDealPredictionHistory returns every stored prediction for a deal across model versions
+func GetDealPredictionHistory(c *gin.Context) {
+	id := c.Param("id")
+	var predictions []models.Prediction
+
+	if err := db.Where("deal_id = ?", id).Order("created_at").Find(&predictions).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch predictions"})
+		return
+	}
+
+	c.JSON(http.StatusOK, predictions)
+}
+
+// GetPredictionAccuracy compares stored predictions with the actual outcomes of
+// their deals. Only deals the model was not trained on count, so the figures
+// are out-of-sample.
+func GetPredictionAccuracy(c *gin.Context) {
+	version := c.DefaultQuery("model_version", "")
+	if version == "" && predictor != nil {
+		version = predictor.Version
+	}
+
+	// The model's training set tells in-sample predictions apart
+	model, err := modelForVersion(version)
+	if err != nil {
+		c.JSON(http.StatusServiceUnavailable, gin.H{"error": err.Error()})
+		return
+	}
+
+	var predictions []models.Prediction
+	if err := db.Where("model_version = ?", version).Find(&predictions).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch predictions"})
+		return
+	}
+
+	var deals []models.Deal
+	if err := db.Find(&deals).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch deals"})
+		return
+	}
+	dealsByID := make(map[uint]models.Deal, len(deals))
+	for _, deal := range deals {
+		dealsByID[deal.ID] = deal
+	}
+
+	scores := make([]float64, 0, len(predictions))
+	labels := make([]bool, 0, len(predictions))
+	equityError, valuationError := 0.0, 0.0
+	funded, valued, inSample := 0, 0, 0
+
+	for _, prediction := range predictions {
+		deal, ok := dealsByID[prediction.DealID]
+		if !ok || !outcomeKnown(deal) {
+			continue
+		}
+		if model.Seen(deal.ID) {
+			inSample++
+			continue
+		}
+
+		scores = append(scores, prediction.SuccessProbability)
+		labels = append(labels, ml.Funded(deal))
+
+		// Terms can only be compared for pitches that got a deal
+		if !ml.Funded(deal) {
+			continue
+		}
+		funded++
+		equityError += math.Abs(prediction.PredictedEquity - deal.DealEquity)
+		if actual := ml.DealValuation(deal); actual > 0 && prediction.PredictedValuation > 0 {
+			valued++
+			valuationError += math.Abs(math.Log(prediction.PredictedValuation / actual))
+		}
+	}
+
+	result := gin.H{
+		"model_version": version,
+		"predictions":   len(predictions),
+		"in_sample":     inSample,
+		"with_outcome":  len(scores),
+	}
+	if len(scores) > 0 {
+		confusion := ml.ConfusionAt(scores, labels, 0.5)
+		result["accuracy"] = float64(confusion.TruePositives+confusion.TrueNegatives) / float64(len(scores))
+		result["brier_score"] = ml.BrierScore(scores, labels)
+		result["auc"] = ml.AUC(scores, labels)
+		result["calibration"] = ml.Calibration(scores, labels, 10)
+		result["confusion_matrix"] = confusion
+	}
+	if funded > 0 {
+		result["equity_mae"] = equityError / float64(funded)
+	}
+	if valued > 0 {
+		// Mean absolute log error, i.e. the typical factor the valuation was off by
+		result["valuation_mean_abs_log_error"] = valuationError / float64(valued)
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+// GetPredictionDrift compares a season's deals with every earlier season, using the loaded model
+func GetPredictionDrift(c *gin.Context) {
+	if predictor == nil {
+		c.JSON(http.StatusServiceUnavailable, gin.H{"error": "No prediction model loaded"})
+		return
+	}
+
+	var deals []models.Deal
+	if err := db.Find(&deals).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch deals"})
+		return
+	}
+
+	// Default to the latest season
+	season := 0
+	for _, deal := range deals {
+		if deal.Season > season {
+			season = deal.Season
+		}
+	}
+	if s := c.DefaultQuery("season", ""); s != "" {
+		seasonNum, err := strconv.Atoi(s)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid season"})
+			return
+		}
+		season = seasonNum
+	}
+
+	report := SeasonDrift(predictor, deals, season)
+
+	c.JSON(http.StatusOK, gin.H{
+		"season":        season,
+		"model_version": predictor.Version,
+		"drift":         report,
+	})
+}
+
+// SeasonDrift compares one season's deals with the deals of all earlier seasons
+func SeasonDrift(model *ml.Model, deals []models.Deal, season int) ml.DriftReport {
+	var baseline, current []models.Deal
+	for _, deal := range deals {
+		switch {
+		case deal.Season < season:
+			baseline = append(baseline, deal)
+		case deal.Season == season:
+			current = append(current, deal)
+		}
+	}
+	return ml.Drift(model, baseline, current)
+}
+
+// Helper functions
+
+// outcomeKnown reports whether a deal's pitch has aired and its outcome was recorded
+func outcomeKnown(deal models.Deal) bool {
+	status := strings.ToLower(strings.TrimSpace(deal.SuccessStatus))
+	return (status != "" && status != "pending") || deal.DealAmount > 0
+}
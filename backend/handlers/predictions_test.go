@@ -0,0 +1,56 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/your-username/shark-tank-analytics/ml"
+	"github.com/your-username/shark-tank-analytics/models"
+)
+
+func TestGetPredictionAccuracyIsOutOfSample(t *testing.T) {
+	setupTestDB(t)
+
+	previous := predictor
+	predictor = &ml.Model{Version: "v1", TrainingDeals: []uint{1, 2}}
+	t.Cleanup(func() { predictor = previous })
+
+	deals := []models.Deal{
+		{ID: 1, SuccessStatus: "funded", DealAmount: 1e6, DealEquity: 10},
+		{ID: 2, SuccessStatus: "not funded"},
+		{ID: 3, SuccessStatus: "funded", DealAmount: 2e6, DealEquity: 5},
+		{ID: 4, SuccessStatus: "not funded"},
+	}
+	if err := db.Create(&deals).Error; err != nil {
+		t.Fatal(err)
+	}
+	predictions := []models.Prediction{
+		{DealID: 1, SuccessProbability: 0.9, ModelVersion: "v1"},
+		{DealID: 2, SuccessProbability: 0.1, ModelVersion: "v1"},
+		{DealID: 3, SuccessProbability: 0.4, ModelVersion: "v1"},
+		{DealID: 4, SuccessProbability: 0.6, ModelVersion: "v1"},
+	}
+	if err := db.Create(&predictions).Error; err != nil {
+		t.Fatal(err)
+	}
+
+	rec := serve(t, GetPredictionAccuracy, http.MethodGet, "/predictions/accuracy", "/predictions/accuracy", nil)
+	expectStatus(t, rec, http.StatusOK)
+
+	var result struct {
+		InSample    int     `json:"in_sample"`
+		WithOutcome int     `json:"with_outcome"`
+		Accuracy    float64 `json:"accuracy"`
+	}
+	if err := json.Unmarshal(rec.Body.Bytes(), &result); err != nil {
+		t.Fatal(err)
+	}
+	if result.InSample != 2 || result.WithOutcome != 2 {
+		t.Fatalf("in_sample = %d, with_outcome = %d, want 2 and 2", result.InSample, result.WithOutcome)
+	}
+	// Both held-out predictions are on the wrong side of 0.5
+	if result.Accuracy != 0 {
+		t.Errorf("accuracy = %v, want 0", result.Accuracy)
+	}
+}
@@ -0,0 +1,91 @@
+package handlers
+
+import (
+	"fmt"
+	"log"
+	"strconv"
+	"sync"
+
+	"github.com/gin-gonic/gin"
+	"github.com/your-username/shark-tank-analytics/ml"
+	"github.com/your-username/shark-tank-analytics/models"
+)
+
+// predictor is the deal outcome model loaded at startup, nil when none is available
+var predictor *ml.Model
+
+// ModelPath returns the artifact of a model version, set by main so that models
+// other than the served one can be loaded on demand
+var ModelPath func(version string) string
+
+// Models loaded by version, besides the served predictor
+var (
+	versionedModels   = make(map[string]*ml.Model)
+	versionedModelsMu sync.Mutex
+)
+
+// LoadPredictor loads the deal outcome model used by the prediction endpoints
+func LoadPredictor(path string) (*ml.Model, error) {
+	model, err := ml.Load(path)
+	if err != nil {
+		return nil, err
+	}
+
+	predictor = model
+	log.Printf("Loaded deal model %s trained on %d deals", model.Version, model.TrainingSize)
+	return model, nil
+}
+
+// Helper functions
+
+// modelForVersion returns the model of a version, the served predictor when
+// the versions match and otherwise the version's artifact
+func modelForVersion(version string) (*ml.Model, error) {
+	if predictor != nil && predictor.Version == version {
+		return predictor, nil
+	}
+	if ModelPath == nil || !ml.ValidVersion(version) {
+		return nil, fmt.Errorf("model version %q is not available", version)
+	}
+
+	versionedModelsMu.Lock()
+	defer versionedModelsMu.Unlock()
+
+	if model, ok := versionedModels[version]; ok {
+		return model, nil
+	}
+	model, err := ml.Load(ModelPath(version))
+	if err != nil {
+		return nil, fmt.Errorf("model version %q is not available: %v", version, err)
+	}
+	versionedModels[version] = model
+	return model, nil
+}
+
+// pitchFromQuery reads the ask side of a pitch from query parameters
+func pitchFromQuery(c *gin.Context) models.Deal {
+	number := func(key string) float64 {
+		v, _ := strconv.ParseFloat(c.DefaultQuery(key, "0"), 64)
+		return v
+	}
+
+	// Revenue and margin left out of the query are unknown rather than zero
+	optionalNumber := func(key string) *float64 {
+		v, err := strconv.ParseFloat(c.Query(key), 64)
+		if err != nil {
+			return nil
+		}
+		return &v
+	}
+
+	return models.Deal{
+		Industry:       c.DefaultQuery("industry", ""),
+		AskAmount:      number("ask_amount"),
+		AskEquity:      number("ask_equity"),
+		RevenueCurrent: optionalNumber("revenue_current"),
+		ProfitMargin:   optionalNumber("profit_margin"),
+		TeamSize:       int(number("team_size")),
+		Location:       c.DefaultQuery("location", ""),
+		PatentStatus:   c.DefaultQuery("patent_status", ""),
+	}
+}
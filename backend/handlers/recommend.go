@@ -0,0 +1,271 @@
+package handlers
+
+import (
+	"fmt"
+	"math"
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/your-username/shark-tank-analytics/ml"
+	"github.com/your-username/shark-tank-analytics/models"
+)
+
+// Number of nearest past deals the recommender scores sharks against
+const recommendNeighbors = 25
+
+// Weight of a shark's overall investment rate when few similar deals exist
+const recommendPriorWeight = 2.0
+
+// RecommendSharks ranks sharks by how likely they are to invest in a pitch
+func RecommendSharks(c *gin.Context) {
+	var input struct {
+		models.Deal
+		Stage string `json:"stage"`
+	}
+
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	var deals []models.Deal
+	if err := db.Find(&deals).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch deals"})
+		return
+	}
+
+	var sharks []models.Shark
+	db.Find(&sharks)
+
+	recommendations := recommendSharks(input.Deal, input.Stage, deals, sharks)
+
+	c.JSON(http.StatusOK, recommendations)
+}
+
+// Helper functions
+
+type sharkRecommendation struct {
+	Name               string        `json:"name"`
+	SharkID            string        `json:"shark_id,omitempty"`
+	Likelihood         float64       `json:"likelihood"`
+	BaseRate           float64       `json:"base_rate"`
+	NearestDeals       []similarDeal `json:"nearest_deals"`
+	MatchedPreferences []string      `json:"matched_preferences"`
+}
+
+type similarDeal struct {
+	DealID      uint    `json:"deal_id"`
+	StartupName string  `json:"startup_name"`
+	Industry    string  `json:"industry"`
+	Season      int     `json:"season"`
+	Similarity  float64 `json:"similarity"`
+}
+
+type scoredDeal struct {
+	deal       models.Deal
+	similarity float64
+}
+
+// recommendSharks scores every shark that has invested before against the pitch.
+//
+// The likelihood is the similarity-weighted share of the nearest past deals the
+// shark invested in, smoothed towards the shark's overall investment rate so that
+// a single similar deal does not produce a certainty.
+func recommendSharks(pitch models.Deal, stage string, deals []models.Deal, sharks []models.Shark) []sharkRecommendation {
+	// Only infer the stage when the pitch says something about revenue
+	if stage == "" && ml.Revenue(pitch) > 0 {
+		stage = dealStage(pitch)
+	}
+
+	neighbors := nearestDeals(pitch, stage, deals, recommendNeighbors)
+
+	// Overall investment rate per shark across all pitches
+	invested := make(map[string]int)
+	for _, deal := range deals {
+		for _, shark := range cleanSharkNames(deal.InvestedSharks) {
+			invested[shark]++
+		}
+	}
+
+	profiles := make(map[string]models.Shark)
+	for _, shark := range sharks {
+		profiles[strings.ToLower(shark.Name)] = shark
+	}
+
+	totalSimilarity := 0.0
+	for _, neighbor := range neighbors {
+		totalSimilarity += neighbor.similarity
+	}
+
+	recommendations := make([]sharkRecommendation, 0, len(invested))
+	for name, count := range invested {
+		baseRate := float64(count) / float64(len(deals))
+
+		weighted := 0.0
+		nearest := make([]similarDeal, 0)
+		for _, neighbor := range neighbors {
+			if !hasShark(neighbor.deal.InvestedSharks, name) {
+				continue
+			}
+			weighted += neighbor.similarity
+			if len(nearest) < 3 {
+				nearest = append(nearest, similarDeal{
+					DealID:      neighbor.deal.ID,
+					StartupName: neighbor.deal.StartupName,
+					Industry:    neighbor.deal.Industry,
+					Season:      neighbor.deal.Season,
+					Similarity:  neighbor.similarity,
+				})
+			}
+		}
+
+		recommendation := sharkRecommendation{
+			Name:         name,
+			Likelihood:   (weighted + recommendPriorWeight*baseRate) / (totalSimilarity + recommendPriorWeight),
+			BaseRate:     baseRate,
+			NearestDeals: nearest,
+		}
+
+		profile, hasProfile := profiles[strings.ToLower(name)]
+		if hasProfile {
+			recommendation.SharkID = profile.ID
+		}
+		recommendation.MatchedPreferences = matchedPreferences(pitch, name, profile, hasProfile, deals)
+
+		recommendations = append(recommendations, recommendation)
+	}
+
+	sort.Slice(recommendations, func(i, j int) bool {
+		return recommendations[i].Likelihood > recommendations[j].Likelihood
+	})
+
+	return recommendations
+}
+
+// nearestDeals returns the k past deals most similar to the pitch, most similar first
+func nearestDeals(pitch models.Deal, stage string, deals []models.Deal, k int) []scoredDeal {
+	scored := make([]scoredDeal, 0, len(deals))
+	for _, deal := range deals {
+		if deal.ID != 0 && deal.ID == pitch.ID {
+			continue
+		}
+		scored = append(scored, scoredDeal{deal: deal, similarity: pitchSimilarity(pitch, stage, deal)})
+	}
+
+	sort.SliceStable(scored, func(i, j int) bool {
+		return scored[i].similarity > scored[j].similarity
+	})
+
+	if len(scored) > k {
+		scored = scored[:k]
+	}
+	return scored
+}
+
+// pitchSimilarity scores a past deal against a pitch between 0 and 1. Each feature
+// the pitch leaves empty is skipped rather than counted as a mismatch.
+func pitchSimilarity(pitch models.Deal, stage string, deal models.Deal) float64 {
+	score, weight := 0.0, 0.0
+
+	add := func(w, s float64) {
+		score += w * s
+		weight += w
+	}
+
+	if pitch.Industry != "" {
+		add(3, boolScore(strings.EqualFold(strings.TrimSpace(pitch.Industry), strings.TrimSpace(deal.Industry))))
+	}
+	if pitch.AskAmount > 0 && deal.AskAmount > 0 {
+		add(2, math.Exp(-math.Abs(math.Log(pitch.AskAmount/deal.AskAmount))))
+	}
+	if pitch.AskEquity > 0 && deal.AskEquity > 0 {
+		add(1, math.Exp(-math.Abs(pitch.AskEquity-deal.AskEquity)/10))
+	}
+	if ml.Revenue(pitch) > 0 {
+		add(1.5, math.Exp(-math.Abs(math.Log1p(ml.Revenue(pitch))-math.Log1p(ml.Revenue(deal)))/2))
+	}
+	if stage != "" {
+		add(1, boolScore(stage == dealStage(deal)))
+	}
+	if pitch.Location != "" {
+		add(0.5, boolScore(strings.EqualFold(strings.TrimSpace(pitch.Location), strings.TrimSpace(deal.Location))))
+	}
+
+	if weight == 0 {
+		return 0
+	}
+	return score / weight
+}
+
+// matchedPreferences lists the reasons a shark fits the pitch, from the shark's
+// profile when one exists and from the deals they did on the show
+func matchedPreferences(pitch models.Deal, name string, profile models.Shark, hasProfile bool, deals []models.Deal) []string {
+	matched := make([]string, 0)
+
+	if hasProfile {
+		for _, industry := range profile.IndustryPreference {
+			if strings.EqualFold(industry, pitch.Industry) {
+				matched = append(matched, "Lists "+industry+" as a preferred industry")
+			}
+		}
+		r := profile.InvestmentRange
+		if pitch.AskAmount > 0 && r.Max > 0 && pitch.AskAmount >= r.Min && pitch.AskAmount <= r.Max {
+			matched = append(matched, "Ask is within their stated investment range")
+		}
+	}
+
+	industryDeals := 0
+	minAsk, maxAsk := math.Inf(1), math.Inf(-1)
+	for _, deal := range deals {
+		if !hasShark(deal.InvestedSharks, name) {
+			continue
+		}
+		if pitch.Industry != "" && strings.EqualFold(deal.Industry, pitch.Industry) {
+			industryDeals++
+		}
+		minAsk = math.Min(minAsk, deal.AskAmount)
+		maxAsk = math.Max(maxAsk, deal.AskAmount)
+	}
+
+	if industryDeals > 0 {
+		matched = append(matched, fmt.Sprintf("Invested in %d %s pitches", industryDeals, pitch.Industry))
+	}
+	if pitch.AskAmount > 0 && pitch.AskAmount >= minAsk && pitch.AskAmount <= maxAsk {
+		matched = append(matched, "Ask is within the range of asks they have funded")
+	}
+
+	return matched
+}
+
+// dealStage buckets a startup by its current revenue
+func dealStage(deal models.Deal) string {
+	revenue := ml.Revenue(deal)
+	switch {
+	case revenue <= 0:
+		return "pre-revenue"
+	case revenue < 1e7:
+		return "early"
+	case revenue < 1e8:
+		return "growth"
+	default:
+		return "scale"
+	}
+}
+
+func hasShark(sharks []string, name string) bool {
+	for _, shark := range cleanSharkNames(sharks) {
+		if strings.EqualFold(shark, name) {
+			return true
+		}
+	}
+	return false
+}
+
+func boolScore(b bool) float64 {
+	if b {
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,44 @@
+package handlers
+
+import (
+	"log"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/your-username/shark-tank-analytics/models"
+	"github.com/your-username/shark-tank-analytics/risk"
+)
+
+// RiskConfig holds the risk score thresholds, DefaultConfig until LoadRiskConfig runs
+var RiskConfig = risk.DefaultConfig
+
+// LoadRiskConfig loads the risk score thresholds, falling back to the defaults
+// when the file is missing or invalid
+func LoadRiskConfig(path string) {
+	cfg, err := risk.LoadConfig(path)
+	if err != nil {
+		log.Printf("Using default risk thresholds, could not load %s: %v", path, err)
+	}
+	RiskConfig = cfg
+}
+
+// GetDealRisk returns the risk score of a deal broken down into its components
+func GetDealRisk(c *gin.Context) {
+	id := c.Param("id")
+	var deal models.Deal
+
+	if err := db.First(&deal, id).Error; err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Deal not found"})
+		return
+	}
+
+	result := risk.Score(deal, RiskConfig)
+
+	c.JSON(http.StatusOK, gin.H{
+		"deal_id":      deal.ID,
+		"startup_name": deal.StartupName,
+		"risk_score":   result.Score,
+		"components":   result.Components,
+		"thresholds":   RiskConfig,
+	})
+}
@@ -0,0 +1,247 @@
+package handlers
+
+import (
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/your-username/shark-tank-analytics/models"
+	"github.com/your-username/shark-tank-analytics/stats"
+	"gorm.io/gorm"
+)
+
+// GetSeasonRoster returns which sharks sat on each episode of a season
+func GetSeasonRoster(c *gin.Context) {
+	season, err := strconv.Atoi(c.Param("n"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid season"})
+		return
+	}
+
+	var appearances []models.SharkAppearance
+	if err := db.Where("season = ?", season).Order("episode, shark_name").Find(&appearances).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch roster"})
+		return
+	}
+
+	episodes := make(map[int][]models.SharkAppearance)
+	sharks := make(map[string]gin.H)
+	for _, appearance := range appearances {
+		episodes[appearance.Episode] = append(episodes[appearance.Episode], appearance)
+
+		if sharks[appearance.SharkID] == nil {
+			sharks[appearance.SharkID] = gin.H{
+				"shark_id":   appearance.SharkID,
+				"name":       appearance.SharkName,
+				"episodes":   0,
+				"guest_only": true,
+			}
+		}
+		shark := sharks[appearance.SharkID]
+		shark["episodes"] = shark["episodes"].(int) + 1
+		if !appearance.Guest {
+			shark["guest_only"] = false
+		}
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"season":   season,
+		"episodes": episodes,
+		"sharks":   sharks,
+	})
+}
+
+// SetEpisodeRoster replaces the panel of sharks recorded for an episode
+func SetEpisodeRoster(c *gin.Context) {
+	season, err := strconv.Atoi(c.Param("n"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid season"})
+		return
+	}
+	episode, err := strconv.Atoi(c.Param("e"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid episode"})
+		return
+	}
+
+	var input []struct {
+		SharkID string `json:"shark_id"`
+		Guest   bool   `json:"guest"`
+	}
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	appearances := make([]models.SharkAppearance, 0, len(input))
+	seen := make(map[string]bool)
+	for _, entry := range input {
+		if seen[entry.SharkID] {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Shark listed twice: " + entry.SharkID})
+			return
+		}
+		seen[entry.SharkID] = true
+
+		var shark models.Shark
+		if err := db.First(&shark, "id = ?", entry.SharkID).Error; err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Unknown shark: " + entry.SharkID})
+			return
+		}
+		appearances = append(appearances, models.SharkAppearance{
+			SharkID:   shark.ID,
+			SharkName: shark.Name,
+			Season:    season,
+			Episode:   episode,
+			Guest:     entry.Guest,
+		})
+	}
+
+	// Replace the roster in one transaction so a failed insert keeps the old one
+	err = db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("season = ? AND episode = ?", season, episode).Delete(&models.SharkAppearance{}).Error; err != nil {
+			return err
+		}
+		if len(appearances) == 0 {
+			return nil
+		}
+		return tx.Create(&appearances).Error
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update roster"})
+		return
+	}
+
+	c.JSON(http.StatusOK, appearances)
+}
+
+// GetSharkDealRates returns each shark's deal rate over the pitches they sat
+// through, with its Wilson interval and a test of whether the rates differ
+// between sharks
+func GetSharkDealRates(c *gin.Context) {
+	season := c.DefaultQuery("season", "")
+
+	appearanceQuery := db.Model(&models.SharkAppearance{})
+	dealQuery := db.Model(&models.Deal{})
+
+	if season != "" {
+		if seasonNum, err := strconv.Atoi(season); err == nil {
+			appearanceQuery = appearanceQuery.Where("season = ?", seasonNum)
+			dealQuery = dealQuery.Where("season = ?", seasonNum)
+		}
+	}
+
+	var appearances []models.SharkAppearance
+	if err := appearanceQuery.Find(&appearances).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch roster"})
+		return
+	}
+
+	var deals []models.Deal
+	if err := dealQuery.Find(&deals).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch deals"})
+		return
+	}
+
+	rates, funded, pitches := opportunityAdjustedRates(appearances, deals)
+
+	// A pitch is heard by every shark on the panel, so the groups overlap
+	c.JSON(http.StatusOK, gin.H{
+		"sharks": rates,
+		"comparison": gin.H{
+			"deal_rate":          stats.CompareRates(funded, pitches),
+			"overlapping_groups": true,
+		},
+	})
+}
+
+// Helper functions
+
+// opportunityAdjustedRates computes, for every shark on the roster, the deal
+// rate over the pitches of the episodes they sat on and their deals per episode
+// present. Pitches from episodes without a roster count for no shark. It also
+// returns the deal and pitch counts of the sharks in the order of the result,
+// for comparing the rates. Sharks are matched to deals by name since deals only
+// record names.
+func opportunityAdjustedRates(appearances []models.SharkAppearance, deals []models.Deal) ([]gin.H, []int, []int) {
+	type sharkRate struct {
+		id       string
+		name     string
+		episodes int
+		guest    int
+		pitches  int
+		deals    int
+	}
+
+	rates := make(map[string]*sharkRate)
+	panels := make(map[[2]int][]string)
+	for _, appearance := range appearances {
+		key := strings.ToLower(appearance.SharkName)
+		if rates[key] == nil {
+			rates[key] = &sharkRate{id: appearance.SharkID, name: appearance.SharkName}
+		}
+		rates[key].episodes++
+		if appearance.Guest {
+			rates[key].guest++
+		}
+		episode := [2]int{appearance.Season, appearance.Episode}
+		panels[episode] = append(panels[episode], key)
+	}
+
+	for _, deal := range deals {
+		invested := make(map[string]bool)
+		for _, shark := range cleanSharkNames(deal.InvestedSharks) {
+			invested[strings.ToLower(shark)] = true
+		}
+		for _, key := range panels[[2]int{deal.Season, deal.Episode}] {
+			rates[key].pitches++
+			if invested[key] {
+				rates[key].deals++
+			}
+		}
+	}
+
+	ordered := make([]*sharkRate, 0, len(rates))
+	for _, rate := range rates {
+		ordered = append(ordered, rate)
+	}
+	sort.Slice(ordered, func(i, j int) bool {
+		return float64(ordered[i].deals)/float64(ordered[i].episodes) > float64(ordered[j].deals)/float64(ordered[j].episodes)
+	})
+
+	result := make([]gin.H, 0, len(ordered))
+	funded := make([]int, 0, len(ordered))
+	pitches := make([]int, 0, len(ordered))
+	for _, rate := range ordered {
+		result = append(result, gin.H{
+			"shark_id":          rate.id,
+			"name":              rate.name,
+			"episodes_present":  rate.episodes,
+			"guest_episodes":    rate.guest,
+			"guest":             rate.guest == rate.episodes,
+			"pitches_present":   rate.pitches,
+			"total_deals":       rate.deals,
+			"deal_rate":         stats.NewRate(rate.deals, rate.pitches),
+			"deals_per_episode": float64(rate.deals) / float64(rate.episodes),
+		})
+		funded = append(funded, rate.deals)
+		pitches = append(pitches, rate.pitches)
+	}
+
+	return result, funded, pitches
+}
+
+// seasonsFromRoster returns the sorted seasons a shark appeared in
+func seasonsFromRoster(appearances []models.SharkAppearance) []int {
+	seen := make(map[int]bool)
+	seasons := make([]int, 0)
+	for _, appearance := range appearances {
+		if !seen[appearance.Season] {
+			seen[appearance.Season] = true
+			seasons = append(seasons, appearance.Season)
+		}
+	}
+	sort.Ints(seasons)
+	return seasons
+}
@@ -0,0 +1,135 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/your-username/shark-tank-analytics/models"
+	"github.com/your-username/shark-tank-analytics/stats"
+)
+
+func TestGetSharkDealRates(t *testing.T) {
+	setupTestDB(t)
+
+	appearances := []models.SharkAppearance{
+		{SharkID: "aman-gupta", SharkName: "Aman Gupta", Season: 1, Episode: 1},
+		{SharkID: "aman-gupta", SharkName: "Aman Gupta", Season: 1, Episode: 2},
+		{SharkID: "ashneer-grover", SharkName: "Ashneer Grover", Season: 1, Episode: 1},
+	}
+	if err := db.Create(&appearances).Error; err != nil {
+		t.Fatal(err)
+	}
+	deals := []models.Deal{
+		{Season: 1, Episode: 1, InvestedSharks: models.SharkList{"Aman Gupta"}},
+		{Season: 1, Episode: 1},
+		{Season: 1, Episode: 2, InvestedSharks: models.SharkList{"Aman Gupta"}},
+		{Season: 1, Episode: 2},
+		// No roster for this episode, so it counts for nobody
+		{Season: 1, Episode: 3, InvestedSharks: models.SharkList{"Ashneer Grover"}},
+	}
+	if err := db.Create(&deals).Error; err != nil {
+		t.Fatal(err)
+	}
+
+	rec := serve(t, GetSharkDealRates, http.MethodGet, "/sharks/deal-rates", "/sharks/deal-rates", nil)
+	expectStatus(t, rec, http.StatusOK)
+
+	var result struct {
+		Sharks []struct {
+			Name            string     `json:"name"`
+			EpisodesPresent int        `json:"episodes_present"`
+			PitchesPresent  int        `json:"pitches_present"`
+			TotalDeals      int        `json:"total_deals"`
+			DealRate        stats.Rate `json:"deal_rate"`
+		} `json:"sharks"`
+		Comparison struct {
+			DealRate stats.Test `json:"deal_rate"`
+		} `json:"comparison"`
+	}
+	if err := json.Unmarshal(rec.Body.Bytes(), &result); err != nil {
+		t.Fatal(err)
+	}
+
+	want := map[string][3]int{
+		"Aman Gupta":     {2, 4, 2},
+		"Ashneer Grover": {1, 2, 0},
+	}
+	if len(result.Sharks) != len(want) {
+		t.Fatalf("got %d sharks, want %d", len(result.Sharks), len(want))
+	}
+	for _, shark := range result.Sharks {
+		w := want[shark.Name]
+		if shark.EpisodesPresent != w[0] || shark.PitchesPresent != w[1] || shark.TotalDeals != w[2] {
+			t.Errorf("%s: episodes %d, pitches %d, deals %d, want %v",
+				shark.Name, shark.EpisodesPresent, shark.PitchesPresent, shark.TotalDeals, w)
+		}
+		if shark.DealRate.Total != w[1] || shark.DealRate.Successes != w[2] {
+			t.Errorf("%s: deal rate %+v, want %d of %d", shark.Name, shark.DealRate, w[2], w[1])
+		}
+		if shark.DealRate.Low > shark.DealRate.Value || shark.DealRate.High < shark.DealRate.Value {
+			t.Errorf("%s: interval [%v, %v] does not contain %v", shark.Name, shark.DealRate.Low, shark.DealRate.High, shark.DealRate.Value)
+		}
+	}
+	if result.Comparison.DealRate.Method == "none" || result.Comparison.DealRate.Method == "" {
+		t.Errorf("comparison method = %q, want a test", result.Comparison.DealRate.Method)
+	}
+}
+
+func TestSetEpisodeRoster(t *testing.T) {
+	setupTestDB(t)
+
+	sharks := []models.Shark{{ID: "aman-gupta", Name: "Aman Gupta"}, {ID: "namita", Name: "Namita Thapar"}}
+	if err := db.Create(&sharks).Error; err != nil {
+		t.Fatal(err)
+	}
+	old := models.SharkAppearance{SharkID: "namita", SharkName: "Namita Thapar", Season: 2, Episode: 4}
+	if err := db.Create(&old).Error; err != nil {
+		t.Fatal(err)
+	}
+
+	roster := []gin.H{{"shark_id": "aman-gupta"}, {"shark_id": "namita", "guest": true}}
+	rec := serve(t, SetEpisodeRoster, http.MethodPut, "/seasons/:n/episodes/:e/roster", "/seasons/2/episodes/4/roster", roster)
+	expectStatus(t, rec, http.StatusOK)
+
+	var stored []models.SharkAppearance
+	db.Where("season = ? AND episode = ?", 2, 4).Order("shark_id").Find(&stored)
+	if len(stored) != 2 {
+		t.Fatalf("stored roster = %+v, want the two sharks sent", stored)
+	}
+	if stored[0].SharkName != "Aman Gupta" || stored[0].Guest || !stored[1].Guest {
+		t.Errorf("stored roster = %+v, want Aman Gupta and Namita Thapar as a guest", stored)
+	}
+
+	tests := []struct {
+		name   string
+		target string
+		roster []gin.H
+	}{
+		{"unknown shark", "/seasons/2/episodes/4/roster", []gin.H{{"shark_id": "ghost"}}},
+		{"shark listed twice", "/seasons/2/episodes/4/roster", []gin.H{{"shark_id": "namita"}, {"shark_id": "namita"}}},
+		{"invalid season", "/seasons/two/episodes/4/roster", []gin.H{{"shark_id": "namita"}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := serve(t, SetEpisodeRoster, http.MethodPut, "/seasons/:n/episodes/:e/roster", tt.target, tt.roster)
+			expectStatus(t, rec, http.StatusBadRequest)
+
+			var count int64
+			db.Model(&models.SharkAppearance{}).Where("season = ? AND episode = ?", 2, 4).Count(&count)
+			if count != 2 {
+				t.Errorf("roster has %d sharks after a rejected update, want the 2 kept", count)
+			}
+		})
+	}
+
+	// An empty roster clears the episode
+	rec = serve(t, SetEpisodeRoster, http.MethodPut, "/seasons/:n/episodes/:e/roster", "/seasons/2/episodes/4/roster", []gin.H{})
+	expectStatus(t, rec, http.StatusOK)
+	var count int64
+	db.Model(&models.SharkAppearance{}).Count(&count)
+	if count != 0 {
+		t.Errorf("roster has %d sharks after clearing it, want none", count)
+	}
+}
@@ -0,0 +1,208 @@
+package handlers
+
+import (
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/your-username/shark-tank-analytics/ml"
+	"github.com/your-username/shark-tank-analytics/models"
+	"github.com/your-username/shark-tank-analytics/stats"
+)
+
+// GetSeasons returns every season summary with the change from the season before
+func GetSeasons(c *gin.Context) {
+	var seasons []models.Season
+
+	if err := db.Order("season_number").Find(&seasons).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch seasons"})
+		return
+	}
+
+	result := make([]gin.H, 0, len(seasons))
+	for i := range seasons {
+		var previous *models.Season
+		if i > 0 {
+			previous = &seasons[i-1]
+		}
+		result = append(result, gin.H{
+			"season": seasons[i],
+			"deltas": seasonDeltas(seasons[i], previous),
+		})
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+// GetSeason returns a season summary with its episodes, the change from the
+// previous season and the season's shark leaderboard
+func GetSeason(c *gin.Context) {
+	number, err := strconv.Atoi(c.Param("n"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid season"})
+		return
+	}
+
+	var season models.Season
+	if err := db.Where("season_number = ?", number).First(&season).Error; err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Season not found"})
+		return
+	}
+
+	var previous *models.Season
+	var earlier models.Season
+	if err := db.Where("season_number < ?", number).Order("season_number DESC").First(&earlier).Error; err == nil {
+		previous = &earlier
+	}
+
+	var deals []models.Deal
+	if err := db.Where("season = ?", number).Order("episode, id").Find(&deals).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch deals"})
+		return
+	}
+
+	var appearances []models.SharkAppearance
+	db.Where("season = ?", number).Find(&appearances)
+
+	c.JSON(http.StatusOK, gin.H{
+		"season":      season,
+		"deltas":      seasonDeltas(season, previous),
+		"episodes":    episodeBreakdown(deals),
+		"leaderboard": sharkLeaderboard(deals, appearances),
+	})
+}
+
+// Helper functions
+
+// seasonDeltas is the change of each statistic from the previous season, with
+// a test of whether the success rate really changed, nil for the first season
+func seasonDeltas(season models.Season, previous *models.Season) gin.H {
+	if previous == nil {
+		return nil
+	}
+	return gin.H{
+		"previous_season":   previous.SeasonNumber,
+		"total_pitches":     season.TotalPitches - previous.TotalPitches,
+		"total_deals":       season.TotalDeals - previous.TotalDeals,
+		"total_investment":  season.TotalInvestment - previous.TotalInvestment,
+		"average_valuation": season.AverageValuation - previous.AverageValuation,
+		"median_valuation":  season.MedianValuation - previous.MedianValuation,
+		"success_rate":      season.SuccessRate - previous.SuccessRate,
+		"investment_growth": growth(season.TotalInvestment, previous.TotalInvestment),
+		"success_rate_test": stats.CompareRates(
+			[]int{season.TotalDeals, previous.TotalDeals},
+			[]int{season.TotalPitches, previous.TotalPitches},
+		),
+	}
+}
+
+// growth is the relative change from previous to current, nil when previous is 0
+func growth(current, previous float64) interface{} {
+	if previous == 0 {
+		return nil
+	}
+	return (current - previous) / previous
+}
+
+func episodeBreakdown(deals []models.Deal) []gin.H {
+	byEpisode := make(map[int][]models.Deal)
+	for _, deal := range deals {
+		byEpisode[deal.Episode] = append(byEpisode[deal.Episode], deal)
+	}
+
+	numbers := make([]int, 0, len(byEpisode))
+	for episode := range byEpisode {
+		numbers = append(numbers, episode)
+	}
+	sort.Ints(numbers)
+
+	episodes := make([]gin.H, 0, len(numbers))
+	for _, episode := range numbers {
+		funded, investment := 0, 0.0
+		startups := make([]string, 0, len(byEpisode[episode]))
+		for _, deal := range byEpisode[episode] {
+			if ml.Funded(deal) {
+				funded++
+				investment += deal.DealAmount
+			}
+			startups = append(startups, deal.StartupName)
+		}
+
+		episodes = append(episodes, gin.H{
+			"episode":            episode,
+			"pitches":            len(byEpisode[episode]),
+			"deals":              funded,
+			"deal_rate":          float64(funded) / float64(len(byEpisode[episode])),
+			"deal_rate_interval": stats.NewRate(funded, len(byEpisode[episode])),
+			"total_investment":   investment,
+			"startups":           startups,
+		})
+	}
+	return episodes
+}
+
+// sharkLeaderboard ranks the sharks of a season by capital invested, splitting
+// shared deals evenly between their sharks
+func sharkLeaderboard(deals []models.Deal, appearances []models.SharkAppearance) []gin.H {
+	type entry struct {
+		deals      int
+		investment float64
+		equity     float64
+		industries map[string]bool
+	}
+
+	entries := make(map[string]*entry)
+	for _, deal := range deals {
+		amount, equity := sharkShare(deal)
+		for _, shark := range cleanSharkNames(deal.InvestedSharks) {
+			if entries[shark] == nil {
+				entries[shark] = &entry{industries: make(map[string]bool)}
+			}
+			e := entries[shark]
+			e.deals++
+			e.investment += amount
+			e.equity += equity
+			if deal.Industry != "" {
+				e.industries[deal.Industry] = true
+			}
+		}
+	}
+
+	present := make(map[string]int)
+	for _, appearance := range appearances {
+		present[strings.ToLower(appearance.SharkName)]++
+	}
+
+	leaderboard := make([]gin.H, 0, len(entries))
+	for name, e := range entries {
+		industries := make([]string, 0, len(e.industries))
+		for industry := range e.industries {
+			industries = append(industries, industry)
+		}
+		sort.Strings(industries)
+
+		leaderboard = append(leaderboard, gin.H{
+			"name":             name,
+			"deals":            e.deals,
+			"total_investment": e.investment,
+			"average_equity":   e.equity / float64(e.deals),
+			"industries":       industries,
+			"episodes_present": present[strings.ToLower(name)],
+		})
+	}
+
+	sort.Slice(leaderboard, func(i, j int) bool {
+		a, b := leaderboard[i]["total_investment"].(float64), leaderboard[j]["total_investment"].(float64)
+		if a != b {
+			return a > b
+		}
+		return leaderboard[i]["name"].(string) < leaderboard[j]["name"].(string)
+	})
+
+	for i, entry := range leaderboard {
+		entry["rank"] = i + 1
+	}
+	return leaderboard
+}
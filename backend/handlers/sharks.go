@@ -1,8 +1,14 @@
 package handlers
 
 import (
+	"encoding/json"
+	"fmt"
+	"io"
 	"net/http"
+	"os"
+	"path/filepath"
 	"strconv"
+	"strings"
 
 	"github.com/gin-gonic/gin"
 	"github.com/your-username/shark-tank-analytics/models"
@@ -20,7 +26,7 @@ func GetSharks(c *gin.Context) {
 	
 	if season != "" {
 		if seasonNum, err := strconv.Atoi(season); err == nil {
-			query = query.Where("? = ANY(season_appearances)", seasonNum)
+			query = query.Where("id IN (SELECT shark_id FROM shark_appearances WHERE season = ?)", seasonNum)
 		}
 	}
 	
@@ -33,7 +39,7 @@ func GetSharks(c *gin.Context) {
 	c.JSON(http.StatusOK, sharks)
 }
 
-// GetSharkByID returns a specific shark by ID
+// GetSharkByID returns a specific shark profile merged with stats computed from the deals
 func GetSharkByID(c *gin.Context) {
 	id := c.Param("id")
 	var shark models.Shark
@@ -43,9 +49,218 @@ func GetSharkByID(c *gin.Context) {
 		return
 	}
 	
+	deals, err := dealsWithShark(shark.Name)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch deals"})
+		return
+	}
+	
+	applyLiveStats(&shark, deals)
+	
+	var appearances []models.SharkAppearance
+	if err := db.Where("shark_id = ?", shark.ID).Find(&appearances).Error; err == nil && len(appearances) > 0 {
+		shark.SeasonAppearances = seasonsFromRoster(appearances)
+	}
+	
+	c.JSON(http.StatusOK, shark)
+}
+
+// CreateShark creates a new shark profile
+func CreateShark(c *gin.Context) {
+	var shark models.Shark
+	
+	if err := c.ShouldBindJSON(&shark); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	
+	if strings.TrimSpace(shark.Name) == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Shark name is required"})
+		return
+	}
+	
+	if shark.ID == "" {
+		shark.ID = sharkSlug(shark.Name)
+	}
+	if !validSharkID(shark.ID) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Shark id must be a slug of lowercase letters, digits and dashes"})
+		return
+	}
+	
+	var existing int64
+	db.Model(&models.Shark{}).Where("id = ?", shark.ID).Count(&existing)
+	if existing > 0 {
+		c.JSON(http.StatusConflict, gin.H{"error": "Shark already exists"})
+		return
+	}
+	
+	if err := db.Create(&shark).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create shark"})
+		return
+	}
+	
+	c.JSON(http.StatusCreated, shark)
+}
+
+// UpdateShark replaces the profile fields of an existing shark
+func UpdateShark(c *gin.Context) {
+	id := c.Param("id")
+	var shark models.Shark
+	
+	if err := db.First(&shark, "id = ?", id).Error; err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Shark not found"})
+		return
+	}
+	
+	var input models.Shark
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	
+	// The id and avatar are managed by the server
+	input.ID = shark.ID
+	input.ProfileImage = shark.ProfileImage
+	input.CreatedAt = shark.CreatedAt
+	
+	if err := db.Save(&input).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update shark"})
+		return
+	}
+	
+	c.JSON(http.StatusOK, input)
+}
+
+// DeleteShark removes a shark profile and its avatar
+func DeleteShark(c *gin.Context) {
+	id := c.Param("id")
+	var shark models.Shark
+	
+	if err := db.First(&shark, "id = ?", id).Error; err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Shark not found"})
+		return
+	}
+	
+	if err := db.Delete(&shark).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to delete shark"})
+		return
+	}
+	
+	if shark.ProfileImage != "" {
+		os.Remove(filepath.Join(UploadDir, "sharks", filepath.Base(shark.ProfileImage)))
+	}
+	
+	c.JSON(http.StatusOK, gin.H{"message": "Shark deleted successfully"})
+}
+
+// UploadSharkAvatar stores an avatar image for a shark on local disk
+func UploadSharkAvatar(c *gin.Context) {
+	id := c.Param("id")
+	var shark models.Shark
+	
+	if err := db.First(&shark, "id = ?", id).Error; err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Shark not found"})
+		return
+	}
+	
+	file, err := c.FormFile("image")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "No image uploaded"})
+		return
+	}
+	
+	ext := strings.ToLower(filepath.Ext(file.Filename))
+	if !allowedAvatarTypes[ext] {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Avatar must be a jpg, png or webp image"})
+		return
+	}
+	
+	if file.Size > maxAvatarSize {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Avatar must be smaller than 5MB"})
+		return
+	}
+	
+	dir := filepath.Join(UploadDir, "sharks")
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to store avatar"})
+		return
+	}
+	
+	// Replace any previous avatar, which may have had a different extension
+	if shark.ProfileImage != "" {
+		os.Remove(filepath.Join(dir, filepath.Base(shark.ProfileImage)))
+	}
+	
+	// The id also names the file, so one stored before ids were checked must not escape dir
+	if !validSharkID(shark.ID) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Shark id is not a valid slug"})
+		return
+	}
+	filename := shark.ID + ext
+	if err := c.SaveUploadedFile(file, filepath.Join(dir, filename)); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to store avatar"})
+		return
+	}
+	
+	shark.ProfileImage = "/uploads/sharks/" + filename
+	if err := db.Model(&shark).Update("profile_image", shark.ProfileImage).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update shark"})
+		return
+	}
+	
 	c.JSON(http.StatusOK, shark)
 }
 
+// SeedSharks bulk loads shark profiles from an uploaded seed file
+func SeedSharks(c *gin.Context) {
+	file, err := c.FormFile("file")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "No file uploaded"})
+		return
+	}
+	
+	f, err := file.Open()
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Failed to read seed file"})
+		return
+	}
+	defer f.Close()
+	
+	sharks, err := decodeSharkSeed(f)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	
+	if err := saveSharks(sharks); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to seed sharks"})
+		return
+	}
+	
+	c.JSON(http.StatusOK, gin.H{"message": "Sharks seeded successfully", "count": len(sharks)})
+}
+
+// LoadSharkSeed bulk loads shark profiles from a seed file on disk.
+//
+// A seed file is a JSON array of shark profiles using the same fields the API
+// returns. Profiles without an id get a slug of their name, a given id must
+// already be such a slug, and profiles whose id already exists are replaced, so
+// a seed file can be loaded repeatedly.
+func LoadSharkSeed(path string) (int, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return 0, err
+	}
+	defer f.Close()
+	
+	sharks, err := decodeSharkSeed(f)
+	if err != nil {
+		return 0, err
+	}
+	
+	return len(sharks), saveSharks(sharks)
+}
+
 // GetSharkAnalytics returns analytics for a specific shark
 func GetSharkAnalytics(c *gin.Context) {
 	id := c.Param("id")
@@ -57,8 +272,11 @@ func GetSharkAnalytics(c *gin.Context) {
 	}
 	
 	// Get deals where this shark invested
-	var deals []models.Deal
-	db.Where("? = ANY(invested_sharks)", shark.Name).Find(&deals)
+	deals, err := dealsWithShark(shark.Name)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch deals"})
+		return
+	}
 	
 	// Calculate analytics
 	totalInvestment := 0.0
@@ -75,6 +293,15 @@ func GetSharkAnalytics(c *gin.Context) {
 	
 	successRate := float64(successfulDeals) / float64(len(deals)) * 100
 	
+	// Deals per episode present, so guest sharks and partial seasons compare fairly
+	var appearances []models.SharkAppearance
+	db.Where("shark_id = ?", shark.ID).Find(&appearances)
+	shark.SeasonAppearances = seasonsFromRoster(appearances)
+	dealsPerEpisode := 0.0
+	if len(appearances) > 0 {
+		dealsPerEpisode = float64(len(deals)) / float64(len(appearances))
+	}
+	
 	analytics := gin.H{
 		"total_deals":       len(deals),
 		"total_investment":  totalInvestment,
@@ -86,6 +313,8 @@ func GetSharkAnalytics(c *gin.Context) {
 			"appearances":     len(shark.SeasonAppearances),
 			"seasons":        shark.SeasonAppearances,
 			"deals_by_season": calculateDealsBySeason(deals),
+			"episodes_present": len(appearances),
+			"deals_per_episode": dealsPerEpisode,
 		},
 	}
 	
@@ -109,6 +338,16 @@ func GetSharkComparison(c *gin.Context) {
 		return
 	}
 	
+	// Deal totals are not stored, compute them for both sharks
+	for _, shark := range []*models.Shark{&shark1, &shark2} {
+		deals, err := dealsWithShark(shark.Name)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch deals"})
+			return
+		}
+		applyLiveStats(shark, deals)
+	}
+	
 	comparison := gin.H{
 		"shark1": gin.H{
 			"name":             shark1.Name,
@@ -131,6 +370,18 @@ func GetSharkComparison(c *gin.Context) {
 	c.JSON(http.StatusOK, comparison)
 }
 
+// UploadDir is the directory uploaded files are stored under and served from
+var UploadDir = "uploads"
+
+const maxAvatarSize = 5 << 20
+
+var allowedAvatarTypes = map[string]bool{
+	".jpg":  true,
+	".jpeg": true,
+	".png":  true,
+	".webp": true,
+}
+
 // Helper functions
 
 func calculateDealsBySeason(deals []models.Deal) map[int]int {
@@ -163,4 +414,122 @@ func compareInvestmentStyles(shark1, shark2 models.Shark) map[string][]string {
 		shark1.Name: shark1.InvestmentStyle,
 		shark2.Name: shark2.InvestmentStyle,
 	}
+}
+
+// cleanSharkNames trims the names split out of comma separated cells and drops empty entries
+func cleanSharkNames(names []string) []string {
+	cleaned := make([]string, 0, len(names))
+	for _, name := range names {
+		if name = strings.TrimSpace(name); name != "" {
+			cleaned = append(cleaned, name)
+		}
+	}
+	return cleaned
+}
+
+// sharkSlug derives a stable id from a shark name, e.g. "Aman Gupta" becomes "aman-gupta"
+func sharkSlug(name string) string {
+	var b strings.Builder
+	dash := false
+	for _, r := range strings.ToLower(strings.TrimSpace(name)) {
+		if (r >= 'a' && r <= 'z') || (r >= '0' && r <= '9') {
+			b.WriteRune(r)
+			dash = false
+		} else if !dash && b.Len() > 0 {
+			b.WriteByte('-')
+			dash = true
+		}
+	}
+	return strings.TrimSuffix(b.String(), "-")
+}
+
+// validSharkID reports whether id is a non-empty slug as made by sharkSlug. Ids
+// appear in URL paths and avatar file names, so nothing else is accepted.
+func validSharkID(id string) bool {
+	return id != "" && sharkSlug(id) == id
+}
+
+func decodeSharkSeed(r io.Reader) ([]models.Shark, error) {
+	var sharks []models.Shark
+	if err := json.NewDecoder(r).Decode(&sharks); err != nil {
+		return nil, fmt.Errorf("invalid seed file: %v", err)
+	}
+	
+	for i := range sharks {
+		if strings.TrimSpace(sharks[i].Name) == "" {
+			return nil, fmt.Errorf("invalid seed file: shark %d has no name", i+1)
+		}
+		if sharks[i].ID == "" {
+			sharks[i].ID = sharkSlug(sharks[i].Name)
+		}
+		if !validSharkID(sharks[i].ID) {
+			return nil, fmt.Errorf("invalid seed file: shark %d has an invalid id %q", i+1, sharks[i].ID)
+		}
+	}
+	
+	return sharks, nil
+}
+
+func saveSharks(sharks []models.Shark) error {
+	for i := range sharks {
+		if err := db.Save(&sharks[i]).Error; err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// dealsWithShark returns the deals a shark invested in
+func dealsWithShark(name string) ([]models.Deal, error) {
+	var deals []models.Deal
+	if err := db.Order("season, episode, id").Find(&deals).Error; err != nil {
+		return nil, err
+	}
+	
+	matched := make([]models.Deal, 0)
+	for _, deal := range deals {
+		for _, shark := range cleanSharkNames(deal.InvestedSharks) {
+			if strings.EqualFold(shark, name) {
+				matched = append(matched, deal)
+				break
+			}
+		}
+	}
+	return matched, nil
+}
+
+// sharkShare returns the part of a deal's amount and equity attributed to a single
+// investing shark, assuming co-investing sharks split the deal evenly
+func sharkShare(deal models.Deal) (amount, equity float64) {
+	investors := len(cleanSharkNames(deal.InvestedSharks))
+	if investors == 0 {
+		return 0, 0
+	}
+	return deal.DealAmount / float64(investors), deal.DealEquity / float64(investors)
+}
+
+// applyLiveStats overwrites the stored deal statistics of a shark with values computed from its deals
+func applyLiveStats(shark *models.Shark, deals []models.Deal) {
+	totalInvestment := 0.0
+	totalEquity := 0.0
+	funded := 0
+	byIndustry := make(map[string]float64)
+	
+	for _, deal := range deals {
+		amount, equity := sharkShare(deal)
+		totalInvestment += amount
+		totalEquity += equity
+		byIndustry[deal.Industry] += amount
+		if deal.SuccessStatus == "funded" {
+			funded++
+		}
+	}
+	
+	shark.TotalDeals = len(deals)
+	shark.TotalInvestment = totalInvestment
+	shark.InvestmentStats.ByIndustry = byIndustry
+	if len(deals) > 0 {
+		shark.AverageEquity = totalEquity / float64(len(deals))
+		shark.InvestmentStats.SuccessRate = float64(funded) / float64(len(deals)) * 100
+	}
 }
\ No newline at end of file
@@ -0,0 +1,211 @@
+package handlers
+
+import (
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/your-username/shark-tank-analytics/models"
+)
+
+// useUploadDir stores uploads in a temporary directory for the rest of the test
+func useUploadDir(t *testing.T) {
+	previous := UploadDir
+	UploadDir = t.TempDir()
+	t.Cleanup(func() { UploadDir = previous })
+}
+
+func TestCreateShark(t *testing.T) {
+	tests := []struct {
+		name   string
+		shark  gin.H
+		status int
+		wantID string
+	}{
+		{"id from name", gin.H{"name": "Aman Gupta", "investment_style": []string{"hands-on"}}, http.StatusCreated, "aman-gupta"},
+		{"slug id", gin.H{"id": "namita", "name": "Namita Thapar"}, http.StatusCreated, "namita"},
+		{"no name", gin.H{"id": "anupam"}, http.StatusBadRequest, ""},
+		{"path in id", gin.H{"id": "../../main", "name": "Peyush Bansal"}, http.StatusBadRequest, ""},
+		{"uppercase id", gin.H{"id": "Vineeta", "name": "Vineeta Singh"}, http.StatusBadRequest, ""},
+		{"name without letters", gin.H{"name": "???"}, http.StatusBadRequest, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			setupTestDB(t)
+
+			rec := serve(t, CreateShark, http.MethodPost, "/sharks", "/sharks", tt.shark)
+			expectStatus(t, rec, tt.status)
+			if tt.wantID == "" {
+				var count int64
+				db.Model(&models.Shark{}).Count(&count)
+				if count != 0 {
+					t.Errorf("stored %d sharks, want none", count)
+				}
+				return
+			}
+
+			var stored models.Shark
+			if err := db.First(&stored, "id = ?", tt.wantID).Error; err != nil {
+				t.Fatalf("shark %q not stored: %v", tt.wantID, err)
+			}
+			if stored.Name != tt.shark["name"] {
+				t.Errorf("stored name = %q, want %q", stored.Name, tt.shark["name"])
+			}
+		})
+	}
+}
+
+func TestCreateSharkConflict(t *testing.T) {
+	setupTestDB(t)
+
+	shark := gin.H{"name": "Aman Gupta"}
+	expectStatus(t, serve(t, CreateShark, http.MethodPost, "/sharks", "/sharks", shark), http.StatusCreated)
+	expectStatus(t, serve(t, CreateShark, http.MethodPost, "/sharks", "/sharks", shark), http.StatusConflict)
+}
+
+func TestUpdateShark(t *testing.T) {
+	setupTestDB(t)
+
+	shark := models.Shark{ID: "aman-gupta", Name: "Aman Gupta", ProfileImage: "/uploads/sharks/aman-gupta.png"}
+	if err := db.Create(&shark).Error; err != nil {
+		t.Fatal(err)
+	}
+
+	input := gin.H{
+		"id":               "someone-else",
+		"name":             "Aman Gupta",
+		"company":          "boAt",
+		"investment_style": []string{"hands-on", "consumer brands"},
+		"profile_image":    "/elsewhere.png",
+	}
+	rec := serve(t, UpdateShark, http.MethodPut, "/sharks/:id", "/sharks/aman-gupta", input)
+	expectStatus(t, rec, http.StatusOK)
+
+	var stored models.Shark
+	if err := db.First(&stored, "id = ?", "aman-gupta").Error; err != nil {
+		t.Fatal(err)
+	}
+	if stored.Company != "boAt" || len(stored.InvestmentStyle) != 2 {
+		t.Errorf("stored shark = %+v, want the updated profile", stored)
+	}
+	if stored.ProfileImage != shark.ProfileImage {
+		t.Errorf("profile image = %q, want the server managed %q", stored.ProfileImage, shark.ProfileImage)
+	}
+
+	var count int64
+	db.Model(&models.Shark{}).Count(&count)
+	if count != 1 {
+		t.Errorf("stored %d sharks, want the id to stay unchanged", count)
+	}
+
+	rec = serve(t, UpdateShark, http.MethodPut, "/sharks/:id", "/sharks/missing", input)
+	expectStatus(t, rec, http.StatusNotFound)
+}
+
+func TestDeleteShark(t *testing.T) {
+	setupTestDB(t)
+	useUploadDir(t)
+
+	avatar := filepath.Join(UploadDir, "sharks", "namita.png")
+	if err := os.MkdirAll(filepath.Dir(avatar), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(avatar, []byte("png"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	shark := models.Shark{ID: "namita", Name: "Namita Thapar", ProfileImage: "/uploads/sharks/namita.png"}
+	if err := db.Create(&shark).Error; err != nil {
+		t.Fatal(err)
+	}
+
+	rec := serve(t, DeleteShark, http.MethodDelete, "/sharks/:id", "/sharks/namita", nil)
+	expectStatus(t, rec, http.StatusOK)
+
+	if err := db.First(&models.Shark{}, "id = ?", "namita").Error; err == nil {
+		t.Error("shark still stored after delete")
+	}
+	if _, err := os.Stat(avatar); !os.IsNotExist(err) {
+		t.Errorf("avatar still on disk after delete: %v", err)
+	}
+
+	rec = serve(t, DeleteShark, http.MethodDelete, "/sharks/:id", "/sharks/namita", nil)
+	expectStatus(t, rec, http.StatusNotFound)
+}
+
+func TestUploadSharkAvatar(t *testing.T) {
+	setupTestDB(t)
+	useUploadDir(t)
+
+	if err := db.Create(&models.Shark{ID: "anupam", Name: "Anupam Mittal"}).Error; err != nil {
+		t.Fatal(err)
+	}
+
+	rec := serveFile(t, UploadSharkAvatar, "/sharks/:id/avatar", "/sharks/anupam/avatar", "image", "face.PNG", []byte("png"))
+	expectStatus(t, rec, http.StatusOK)
+
+	var stored models.Shark
+	if err := db.First(&stored, "id = ?", "anupam").Error; err != nil {
+		t.Fatal(err)
+	}
+	if stored.ProfileImage != "/uploads/sharks/anupam.png" {
+		t.Errorf("profile image = %q, want /uploads/sharks/anupam.png", stored.ProfileImage)
+	}
+	if _, err := os.Stat(filepath.Join(UploadDir, "sharks", "anupam.png")); err != nil {
+		t.Errorf("avatar not stored: %v", err)
+	}
+
+	rec = serveFile(t, UploadSharkAvatar, "/sharks/:id/avatar", "/sharks/anupam/avatar", "image", "face.gif", []byte("gif"))
+	expectStatus(t, rec, http.StatusBadRequest)
+}
+
+func TestSeedSharks(t *testing.T) {
+	tests := []struct {
+		name   string
+		seed   string
+		status int
+		want   []string
+	}{
+		{"ids from names", `[{"name": "Aman Gupta"}, {"id": "namita", "name": "Namita Thapar"}]`, http.StatusOK, []string{"aman-gupta", "namita"}},
+		{"path in id", `[{"id": "../evil", "name": "Aman Gupta"}]`, http.StatusBadRequest, nil},
+		{"missing name", `[{"id": "aman-gupta"}]`, http.StatusBadRequest, nil},
+		{"not json", `sharks`, http.StatusBadRequest, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			setupTestDB(t)
+
+			rec := serveFile(t, SeedSharks, "/sharks/seed", "/sharks/seed", "file", "sharks.json", []byte(tt.seed))
+			expectStatus(t, rec, tt.status)
+
+			var ids []string
+			db.Model(&models.Shark{}).Order("id").Pluck("id", &ids)
+			if len(ids) != len(tt.want) {
+				t.Fatalf("stored ids = %v, want %v", ids, tt.want)
+			}
+			for i := range ids {
+				if ids[i] != tt.want[i] {
+					t.Errorf("stored ids = %v, want %v", ids, tt.want)
+				}
+			}
+		})
+	}
+
+	// Seeding again replaces the stored profiles
+	setupTestDB(t)
+	seed := []byte(`[{"id": "namita", "name": "Namita Thapar", "company": "Emcure"}]`)
+	expectStatus(t, serveFile(t, SeedSharks, "/sharks/seed", "/sharks/seed", "file", "sharks.json", seed), http.StatusOK)
+	seed = []byte(`[{"id": "namita", "name": "Namita Thapar", "company": "Emcure Pharma"}]`)
+	expectStatus(t, serveFile(t, SeedSharks, "/sharks/seed", "/sharks/seed", "file", "sharks.json", seed), http.StatusOK)
+
+	var stored models.Shark
+	if err := db.First(&stored, "id = ?", "namita").Error; err != nil {
+		t.Fatal(err)
+	}
+	if stored.Company != "Emcure Pharma" {
+		t.Errorf("company = %q, want the reseeded Emcure Pharma", stored.Company)
+	}
+}
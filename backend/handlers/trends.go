@@ -0,0 +1,168 @@
+package handlers
+
+import (
+	"math"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/your-username/shark-tank-analytics/ml"
+	"github.com/your-username/shark-tank-analytics/models"
+	"github.com/your-username/shark-tank-analytics/stats"
+)
+
+// GetIndustryTrends returns per-industry series by season with a linear trend
+// forecast for the season after the last one. A metric without any data points
+// has a null forecast.
+func GetIndustryTrends(c *gin.Context) {
+	var deals []models.Deal
+
+	query := db.Model(&models.Deal{})
+	if industry := c.DefaultQuery("industry", ""); industry != "" {
+		query = query.Where("industry = ?", industry)
+	}
+
+	if err := query.Find(&deals).Error; err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch deals"})
+		return
+	}
+
+	minPitches, _ := strconv.Atoi(c.DefaultQuery("min_pitches", "0"))
+
+	// Every season in the data is a point on each industry's series, so an
+	// industry that skipped a season shows zero pitches for it
+	seasonSet := make(map[int]bool)
+	byIndustry := make(map[string]map[int][]models.Deal)
+	for _, deal := range deals {
+		industry := strings.TrimSpace(deal.Industry)
+		if industry == "" {
+			industry = "Unknown"
+		}
+		if byIndustry[industry] == nil {
+			byIndustry[industry] = make(map[int][]models.Deal)
+		}
+		byIndustry[industry][deal.Season] = append(byIndustry[industry][deal.Season], deal)
+		seasonSet[deal.Season] = true
+	}
+
+	seasons := make([]int, 0, len(seasonSet))
+	for season := range seasonSet {
+		seasons = append(seasons, season)
+	}
+	sort.Ints(seasons)
+
+	nextSeason := 1
+	if len(seasons) > 0 {
+		nextSeason = seasons[len(seasons)-1] + 1
+	}
+
+	industries := make([]gin.H, 0, len(byIndustry))
+	for industry, bySeason := range byIndustry {
+		total := 0
+		for _, seasonDeals := range bySeason {
+			total += len(seasonDeals)
+		}
+		if total < minPitches {
+			continue
+		}
+
+		var trend industryTrend
+		series := make([]seasonPoint, 0, len(seasons))
+		for _, season := range seasons {
+			point := newSeasonPoint(season, bySeason[season])
+			series = append(series, point)
+			trend.add(point)
+		}
+
+		industries = append(industries, gin.H{
+			"industry":      industry,
+			"total_pitches": total,
+			"series":        series,
+			"forecast":      trend.forecast(nextSeason),
+		})
+	}
+
+	sort.Slice(industries, func(i, j int) bool {
+		return industries[i]["industry"].(string) < industries[j]["industry"].(string)
+	})
+
+	c.JSON(http.StatusOK, gin.H{
+		"seasons":     seasons,
+		"next_season": nextSeason,
+		"method":      "linear trend over seasons with a 90% prediction interval",
+		"industries":  industries,
+	})
+}
+
+// Helper functions
+
+type seasonPoint struct {
+	Season           int        `json:"season"`
+	Pitches          int        `json:"pitches"`
+	Deals            int        `json:"deals"`
+	DealRate         float64    `json:"deal_rate"`
+	DealRateInterval stats.Rate `json:"deal_rate_interval"`
+	MedianValuation  float64    `json:"median_valuation"`
+	CapitalDeployed  float64    `json:"capital_deployed"`
+}
+
+func newSeasonPoint(season int, deals []models.Deal) seasonPoint {
+	point := seasonPoint{Season: season, Pitches: len(deals)}
+
+	valuations := make([]float64, 0, len(deals))
+	for _, deal := range deals {
+		if ml.Funded(deal) {
+			point.Deals++
+			point.CapitalDeployed += deal.DealAmount
+		}
+		if valuation := ml.AskValuation(deal); valuation > 0 {
+			valuations = append(valuations, valuation)
+		}
+	}
+
+	if point.Pitches > 0 {
+		point.DealRate = float64(point.Deals) / float64(point.Pitches)
+	}
+	point.DealRateInterval = stats.NewRate(point.Deals, point.Pitches)
+	point.MedianValuation = stats.Median(valuations)
+
+	return point
+}
+
+// industryTrend collects the points each metric is forecast from. Deal rate and
+// median valuation are undefined in seasons without pitches and skip them.
+type industryTrend struct {
+	seasons, pitches, capital    []float64
+	rateSeasons, rates           []float64
+	valuationSeasons, valuations []float64
+}
+
+func (t *industryTrend) add(point seasonPoint) {
+	season := float64(point.Season)
+	t.seasons = append(t.seasons, season)
+	t.pitches = append(t.pitches, float64(point.Pitches))
+	t.capital = append(t.capital, point.CapitalDeployed)
+
+	if point.Pitches > 0 {
+		t.rateSeasons = append(t.rateSeasons, season)
+		t.rates = append(t.rates, point.DealRate)
+	}
+	if point.MedianValuation > 0 {
+		t.valuationSeasons = append(t.valuationSeasons, season)
+		t.valuations = append(t.valuations, point.MedianValuation)
+	}
+}
+
+func (t *industryTrend) forecast(season int) gin.H {
+	at := float64(season)
+	return gin.H{
+		"season":           season,
+		"pitches":          stats.ForecastLinear(t.seasons, t.pitches, at).Clamp(0, math.Inf(1)),
+		"deal_rate":        stats.ForecastLinear(t.rateSeasons, t.rates, at).Clamp(0, 1),
+		"median_valuation": stats.ForecastLinear(t.valuationSeasons, t.valuations, at).Clamp(0, math.Inf(1)),
+		"capital_deployed": stats.ForecastLinear(t.seasons, t.capital, at).Clamp(0, math.Inf(1)),
+		"data_points":      len(t.seasons),
+	}
+}
@@ -0,0 +1,100 @@
+package main
+
+import (
+	"bytes"
+	"database/sql"
+	"encoding/json"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/your-username/shark-tank-analytics/handlers"
+)
+
+func init() {
+	gin.SetMode(gin.TestMode)
+}
+
+// setupTestDB points the server and the handlers at a fresh in-memory database
+// with every table created
+func setupTestDB(t *testing.T) {
+	t.Helper()
+
+	conn, err := sql.Open("sqlite", ":memory:")
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Every connection to :memory: is a separate database
+	conn.SetMaxOpenConns(1)
+	t.Cleanup(func() { conn.Close() })
+
+	db = conn
+	createTables()
+	if err := handlers.Connect(db); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// testDeal is the part of a deals row the tests set
+type testDeal struct {
+	Name, Industry, Category, Pitch string
+	Season, Episode                 int
+}
+
+// insertDeal stores a pitch that got no deal and returns its id
+func insertDeal(t *testing.T, deal testDeal) uint {
+	t.Helper()
+
+	result, err := db.Exec(`
+		INSERT INTO deals (
+			season, episode, startup_name, industry, product_category,
+			pitch_description, ask_amount, ask_equity, valuation, deal_amount,
+			deal_equity, deal_debt, multiple_sharks, interested_sharks,
+			invested_sharks, success_status
+		) VALUES (?, ?, ?, ?, ?, ?, 1000000, 5, 20000000, 0, 0, 0, 0, '', '', 'not funded')
+	`, deal.Season, deal.Episode, deal.Name, deal.Industry, deal.Category, deal.Pitch)
+	if err != nil {
+		t.Fatal(err)
+	}
+	id, err := result.LastInsertId()
+	if err != nil {
+		t.Fatal(err)
+	}
+	return uint(id)
+}
+
+// serveAs runs a single request against handler mounted at route as the given
+// user, who is set the way authMiddleware sets it from a JWT, and returns the
+// response. A userID of 0 sends the request without a user.
+func serveAs(t *testing.T, userID int64, handler gin.HandlerFunc, method, route, target string, body interface{}) *httptest.ResponseRecorder {
+	t.Helper()
+
+	var payload bytes.Buffer
+	if body != nil {
+		if err := json.NewEncoder(&payload).Encode(body); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	router := gin.New()
+	router.Handle(method, route, func(c *gin.Context) {
+		if userID != 0 {
+			// JWT claims decode numbers as float64
+			c.Set("user_id", float64(userID))
+		}
+	}, handler)
+
+	req := httptest.NewRequest(method, target, &payload)
+	req.Header.Set("Content-Type", "application/json")
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+	return rec
+}
+
+// expectStatus fails the test when the response does not have the wanted status
+func expectStatus(t *testing.T, rec *httptest.ResponseRecorder, want int) {
+	t.Helper()
+	if rec.Code != want {
+		t.Fatalf("status = %d, want %d: %s", rec.Code, want, rec.Body.String())
+	}
+}
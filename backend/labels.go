@@ -0,0 +1,145 @@
+package main
+
+import (
+	"log"
+	"os"
+	"strconv"
+
+	"github.com/your-username/shark-tank-analytics/handlers"
+	"github.com/your-username/shark-tank-analytics/ml"
+	"github.com/your-username/shark-tank-analytics/models"
+)
+
+// labelDeals trains the pitch text classifier on the stored deals and records
+// its suggestions. Labels entered by hand are never changed: ones the
+// classifier disagrees with are only flagged. A missing industry or category
+// is filled in only when the classifier is more confident than
+// labelFillConfidence, and labels filled in that way are marked so that they
+// are neither trained on nor vouched for by later runs. It returns the number
+// of deals flagged as likely mislabeled.
+func labelDeals() (int, error) {
+	deals, err := loadDeals("")
+	if err != nil {
+		return 0, err
+	}
+
+	filled, err := filledLabels()
+	if err != nil {
+		return 0, err
+	}
+
+	// The classifier only learns from hand-entered labels
+	handLabeled := make([]models.Deal, len(deals))
+	for i, deal := range deals {
+		handLabeled[i] = deal
+		if filled[deal.ID][0] {
+			handLabeled[i].Industry = ""
+		}
+		if filled[deal.ID][1] {
+			handLabeled[i].ProductCategory = ""
+		}
+	}
+	categorizer := handlers.LoadCategorizer(handLabeled)
+	threshold := labelFillConfidence()
+
+	tx, err := db.Begin()
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.Exec("DELETE FROM deal_labels"); err != nil {
+		return 0, err
+	}
+
+	insert, err := tx.Prepare(`
+		INSERT INTO deal_labels (
+			deal_id, suggested_industry, industry_confidence, industry_mislabeled,
+			industry_filled, suggested_category, category_confidence,
+			category_mislabeled, category_filled
+		) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)
+	`)
+	if err != nil {
+		return 0, err
+	}
+	defer insert.Close()
+
+	update, err := tx.Prepare("UPDATE deals SET industry = ?, product_category = ? WHERE id = ?")
+	if err != nil {
+		return 0, err
+	}
+	defer update.Close()
+
+	flagged := 0
+	for i, deal := range handLabeled {
+		s := categorizer.Review(deal)
+		if s.IndustryMislabeled || s.CategoryMislabeled {
+			flagged++
+		}
+
+		// Only empty labels, or ones an earlier run filled in, are written
+		industry, industryFilled := deal.Industry, false
+		if industry == "" && s.Industry != "" && s.IndustryConfidence > threshold {
+			industry, industryFilled = s.Industry, true
+		}
+		category, categoryFilled := deal.ProductCategory, false
+		if category == "" && s.Category != "" && s.CategoryConfidence > threshold {
+			category, categoryFilled = s.Category, true
+		}
+
+		_, err := insert.Exec(
+			deal.ID, s.Industry, s.IndustryConfidence, s.IndustryMislabeled, industryFilled,
+			s.Category, s.CategoryConfidence, s.CategoryMislabeled, categoryFilled,
+		)
+		if err != nil {
+			return 0, err
+		}
+
+		if industry != deals[i].Industry || category != deals[i].ProductCategory {
+			if _, err := update.Exec(industry, category, deal.ID); err != nil {
+				return 0, err
+			}
+		}
+	}
+
+	return flagged, tx.Commit()
+}
+
+// filledLabels returns, per deal, whether its industry and its category were
+// filled in by the classifier and have not been edited since
+func filledLabels() (map[uint][2]bool, error) {
+	rows, err := db.Query(`
+		SELECT l.deal_id,
+			l.industry_filled = 1 AND d.industry = l.suggested_industry,
+			l.category_filled = 1 AND d.product_category = l.suggested_category
+		FROM deal_labels l JOIN deals d ON d.id = l.deal_id
+		WHERE l.industry_filled = 1 OR l.category_filled = 1
+	`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	filled := make(map[uint][2]bool)
+	for rows.Next() {
+		var id uint
+		var industry, category bool
+		if err := rows.Scan(&id, &industry, &category); err != nil {
+			return nil, err
+		}
+		filled[id] = [2]bool{industry, category}
+	}
+	return filled, rows.Err()
+}
+
+// labelFillConfidence is how sure the classifier must be before it fills in
+// a missing label, ml.FillConfidence unless LABEL_FILL_CONFIDENCE is set
+func labelFillConfidence() float64 {
+	if value := os.Getenv("LABEL_FILL_CONFIDENCE"); value != "" {
+		if confidence, err := strconv.ParseFloat(value, 64); err == nil {
+			return confidence
+		}
+		log.Printf("Ignoring invalid LABEL_FILL_CONFIDENCE %q", value)
+	}
+	return ml.FillConfidence
+}
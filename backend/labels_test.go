@@ -0,0 +1,110 @@
+package main
+
+import (
+	"fmt"
+	"testing"
+)
+
+// seedLabeledDeals stores pitches whose text clearly points to their labels
+func seedLabeledDeals(t *testing.T) {
+	t.Helper()
+	for i := 0; i < 6; i++ {
+		insertDeal(t, testDeal{
+			Name: fmt.Sprintf("Snack %d", i), Industry: "Food", Category: "Snacks",
+			Pitch: "healthy snacks chips namkeen made from millets", Season: 1, Episode: 1,
+		})
+		insertDeal(t, testDeal{
+			Name: fmt.Sprintf("App %d", i), Industry: "Technology", Category: "Software",
+			Pitch: "saas app software platform for small businesses", Season: 1, Episode: 2,
+		})
+	}
+}
+
+func dealLabels(t *testing.T, id uint) (industry, category string) {
+	t.Helper()
+	err := db.QueryRow("SELECT industry, product_category FROM deals WHERE id = ?", id).Scan(&industry, &category)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return industry, category
+}
+
+func TestLabelDealsFillsOnlyConfidentMissingLabels(t *testing.T) {
+	setupTestDB(t)
+	seedLabeledDeals(t)
+	t.Setenv("LABEL_FILL_CONFIDENCE", "0.5")
+
+	missing := insertDeal(t, testDeal{Name: "Crunch", Pitch: "millet chips and healthy snacks", Season: 2, Episode: 1})
+	vague := insertDeal(t, testDeal{Name: "Thing", Pitch: "a product", Season: 2, Episode: 1})
+	// Hand-entered labels stay, even when the classifier disagrees
+	handEntered := insertDeal(t, testDeal{
+		Name: "Odd", Industry: "Fashion", Category: "apparel ", Pitch: "saas software app platform", Season: 2, Episode: 2,
+	})
+
+	if _, err := labelDeals(); err != nil {
+		t.Fatal(err)
+	}
+
+	if industry, category := dealLabels(t, missing); industry != "Food" || category != "Snacks" {
+		t.Errorf("missing labels filled with %q, %q, want Food, Snacks", industry, category)
+	}
+	if industry, category := dealLabels(t, vague); industry != "" || category != "" {
+		t.Errorf("labels without confident suggestion filled with %q, %q", industry, category)
+	}
+	if industry, category := dealLabels(t, handEntered); industry != "Fashion" || category != "apparel " {
+		t.Errorf("hand-entered labels rewritten to %q, %q", industry, category)
+	}
+
+	var filled, mislabeled bool
+	err := db.QueryRow("SELECT industry_filled, industry_mislabeled FROM deal_labels WHERE deal_id = ?", missing).Scan(&filled, &mislabeled)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !filled || mislabeled {
+		t.Errorf("filled deal: industry_filled = %v, industry_mislabeled = %v, want true and false", filled, mislabeled)
+	}
+}
+
+func TestLabelDealsRespectsThreshold(t *testing.T) {
+	setupTestDB(t)
+	seedLabeledDeals(t)
+	t.Setenv("LABEL_FILL_CONFIDENCE", "1")
+
+	missing := insertDeal(t, testDeal{Name: "Crunch", Pitch: "millet chips and healthy snacks", Season: 2, Episode: 1})
+	if _, err := labelDeals(); err != nil {
+		t.Fatal(err)
+	}
+	if industry, category := dealLabels(t, missing); industry != "" || category != "" {
+		t.Errorf("labels filled with %q, %q below the threshold", industry, category)
+	}
+}
+
+func TestLabelDealsKeepsEditedFills(t *testing.T) {
+	setupTestDB(t)
+	seedLabeledDeals(t)
+	t.Setenv("LABEL_FILL_CONFIDENCE", "0.5")
+
+	missing := insertDeal(t, testDeal{Name: "Crunch", Pitch: "millet chips and healthy snacks", Season: 2, Episode: 1})
+	if _, err := labelDeals(); err != nil {
+		t.Fatal(err)
+	}
+
+	// An editor corrects the filled label, which makes it a hand-entered one
+	if _, err := db.Exec("UPDATE deals SET industry = 'Beverages' WHERE id = ?", missing); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := labelDeals(); err != nil {
+		t.Fatal(err)
+	}
+
+	if industry, _ := dealLabels(t, missing); industry != "Beverages" {
+		t.Errorf("edited label rewritten to %q", industry)
+	}
+	var filled bool
+	if err := db.QueryRow("SELECT industry_filled FROM deal_labels WHERE deal_id = ?", missing).Scan(&filled); err != nil {
+		t.Fatal(err)
+	}
+	if filled {
+		t.Error("edited label still marked as filled in")
+	}
+}
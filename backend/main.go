@@ -7,11 +7,19 @@ import (
 	"net/http"
 	"os"
 	"path/filepath"
+	"sort"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/golang-jwt/jwt"
 	"github.com/tealeg/xlsx"
+	"github.com/your-username/shark-tank-analytics/geo"
+	"github.com/your-username/shark-tank-analytics/handlers"
+	"github.com/your-username/shark-tank-analytics/models"
+	"github.com/your-username/shark-tank-analytics/risk"
+	"github.com/your-username/shark-tank-analytics/stats"
 	_ "modernc.org/sqlite"
 	"golang.org/x/crypto/bcrypt"
 )
@@ -31,8 +39,75 @@ func main() {
 	// Create tables
 	createTables()
 
+	// Handlers query the same database through gorm
+	if err := handlers.Connect(db); err != nil {
+		log.Fatal(err)
+	}
+
+	// Load risk score thresholds, used by the subcommands as well
+	handlers.LoadRiskConfig(riskConfigPath())
+
+	// Subcommands work on the stored deals instead of serving
+	if len(os.Args) > 1 {
+		switch os.Args[1] {
+		case "train":
+			trainModel(os.Args[2:])
+			return
+		case "backfill":
+			backfillPredictions(os.Args[2:])
+			return
+		case "backtest":
+			runBacktest(os.Args[2:])
+			return
+		case "role":
+			assignRole(os.Args[2:])
+			return
+		}
+	}
+
 	// Import Excel data
-	importExcelData()
+	newSeasons := importExcelData()
+
+	// Classify pitch text to flag mislabeled deals and fill in missing labels
+	if flagged, err := labelDeals(); err != nil {
+		log.Printf("Error labeling deals: %v", err)
+	} else if flagged > 0 {
+		log.Printf("Flagged %d deals as likely mislabeled", flagged)
+	}
+
+	// Summarize seasons from the imported deals
+	if err := refreshSeasons(); err != nil {
+		log.Printf("Error refreshing seasons: %v", err)
+	}
+
+	// Load shark profiles if a seed file is present
+	if _, err := os.Stat("data/sharks.json"); err == nil {
+		if count, err := handlers.LoadSharkSeed("data/sharks.json"); err != nil {
+			log.Printf("Error loading shark seed: %v", err)
+		} else {
+			log.Printf("Loaded %d shark profiles", count)
+		}
+	}
+
+	// Load the configured deal outcome model, predictions are unavailable without one
+	handlers.ModelPath = modelPath
+	if version, err := modelVersion(); err != nil {
+		log.Printf("No deal model loaded, run \"train\" to create one: %v", err)
+	} else if model, err := handlers.LoadPredictor(modelPath(version)); err != nil {
+		log.Printf("Error loading deal model %s: %v", version, err)
+	} else {
+		// Predict deals the model has not scored yet, which covers both a
+		// model change and newly imported deals
+		if count, err := ensurePredictions(model); err != nil {
+			log.Printf("Error backfilling predictions: %v", err)
+		} else if count > 0 {
+			log.Printf("Backfilled %d predictions with model %s", count, model.Version)
+		}
+		logSeasonDrift(model, newSeasons)
+	}
+
+	// Recompute scheduled reports in the background
+	go runReportScheduler()
 
 	// Setup Gin router
 	r := gin.Default()
@@ -40,6 +115,9 @@ func main() {
 	// Enable CORS
 	r.Use(cors())
 
+	// Serve uploaded files such as shark avatars
+	r.Static("/uploads", handlers.UploadDir)
+
 	// Auth routes
 	auth := r.Group("/api/auth")
 	{
@@ -55,8 +133,66 @@ func main() {
 	{
 		api.GET("/deals", getDeals)
 		api.GET("/sharks", getSharks)
+		api.GET("/sharks/bidding-wars", handlers.GetBiddingWars)
+		api.GET("/sharks/deal-rates", handlers.GetSharkDealRates)
+		api.POST("/sharks/recommend", handlers.RecommendSharks)
+		api.GET("/sharks/:id", handlers.GetSharkByID)
+		api.GET("/sharks/:id/portfolio", handlers.GetSharkPortfolio)
+		api.GET("/seasons", handlers.GetSeasons)
+		api.GET("/seasons/:n", handlers.GetSeason)
+		api.GET("/seasons/:n/roster", handlers.GetSeasonRoster)
+		api.GET("/seasons/:n/episodes/:e", handlers.GetEpisode)
+		api.GET("/timeline", handlers.GetTimeline)
 		api.GET("/analytics", getAnalytics)
+		api.GET("/analytics/metrics", getAnalyticsMetrics)
+		api.GET("/analytics/distributions", getDistributions)
+		api.GET("/analytics/geo", getGeoAnalytics)
+		api.GET("/analytics/geo/geojson", getGeoJSON)
+		api.GET("/analytics/teams", getTeamAnalytics)
+		api.GET("/analytics/multiples", getMultiples)
+		api.GET("/analytics/cohorts", getCohorts)
+		api.GET("/trends/industries", handlers.GetIndustryTrends)
+		api.GET("/deals/labels", handlers.GetDealLabels)
+		api.POST("/classify", handlers.ClassifyPitch)
 		api.GET("/predictions", getPredictions)
+		api.GET("/deals/predictions", handlers.GetDealPredictions)
+		api.POST("/predict", handlers.PredictPitch)
+		api.GET("/predictions/accuracy", handlers.GetPredictionAccuracy)
+		api.GET("/predictions/drift", handlers.GetPredictionDrift)
+		api.GET("/predictions/:id/explain", handlers.ExplainPrediction)
+		api.GET("/deals/:id/predictions", handlers.GetDealPredictionHistory)
+		api.GET("/deals/:id/risk", handlers.GetDealRisk)
+		api.GET("/deals/:id/founders", handlers.GetDealFounders)
+		api.GET("/backtests", handlers.GetBacktests)
+		api.GET("/shared/reports/:token", getSharedReport)
+	}
+
+	// Saved report routes, scoped to the logged-in user
+	reports := r.Group("/api/reports", authMiddleware())
+	{
+		reports.GET("", listReports)
+		reports.POST("", createReport)
+		reports.GET("/:id", getReport)
+		reports.PUT("/:id", updateReport)
+		reports.DELETE("/:id", deleteReport)
+		reports.POST("/:id/run", runReport)
+		reports.POST("/:id/share", shareReport)
+		reports.DELETE("/:id/share", unshareReport)
+	}
+
+	// Editor routes
+	editor := r.Group("/api", authMiddleware(), requireEditor())
+	{
+		editor.POST("/sharks", handlers.CreateShark)
+		editor.POST("/sharks/seed", handlers.SeedSharks)
+		editor.PUT("/sharks/:id", handlers.UpdateShark)
+		editor.DELETE("/sharks/:id", handlers.DeleteShark)
+		editor.POST("/sharks/:id/avatar", handlers.UploadSharkAvatar)
+		editor.PUT("/seasons/:n/episodes/:e", handlers.UpdateEpisode)
+		editor.PUT("/seasons/:n/episodes/:e/roster", handlers.SetEpisodeRoster)
+		editor.PUT("/deals/:id/post-show", handlers.UpdatePostShowStatus)
+		editor.PUT("/deals/:id/founders", handlers.SetDealFounders)
+		editor.POST("/backtests", handlers.RunBacktest)
 	}
 
 	// Start server
@@ -73,6 +209,7 @@ func createTables() {
 			full_name TEXT,
 			avatar_url TEXT,
 			preferences TEXT,
+			role TEXT DEFAULT 'viewer',
 			created_at DATETIME DEFAULT CURRENT_TIMESTAMP
 		)
 	`)
@@ -80,6 +217,9 @@ func createTables() {
 		log.Fatal(err)
 	}
 
+	// Databases created before roles existed need the column added
+	addColumnIfMissing("users", "role", "TEXT DEFAULT 'viewer'")
+
 	// Create deals table
 	_, err = db.Exec(`
 		CREATE TABLE IF NOT EXISTS deals (
@@ -97,7 +237,230 @@ func createTables() {
 			multiple_sharks BOOLEAN,
 			interested_sharks TEXT,
 			invested_sharks TEXT,
-			success_status TEXT
+			success_status TEXT,
+			post_show_status TEXT,
+			pitch_description TEXT,
+			product_category TEXT,
+			location TEXT,
+			city TEXT,
+			state TEXT,
+			city_tier INTEGER,
+			team_size INTEGER,
+			founded_year INTEGER,
+			revenue_current REAL,
+			revenue_projected REAL,
+			profit_margin REAL,
+			patent_status TEXT,
+			created_at DATETIME,
+			updated_at DATETIME
+		)
+	`)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	addColumnIfMissing("deals", "post_show_status", "TEXT")
+	addColumnIfMissing("deals", "pitch_description", "TEXT")
+	addColumnIfMissing("deals", "product_category", "TEXT")
+	addColumnIfMissing("deals", "location", "TEXT")
+	addColumnIfMissing("deals", "city", "TEXT")
+	addColumnIfMissing("deals", "state", "TEXT")
+	addColumnIfMissing("deals", "city_tier", "INTEGER")
+	addColumnIfMissing("deals", "team_size", "INTEGER")
+	addColumnIfMissing("deals", "founded_year", "INTEGER")
+	addColumnIfMissing("deals", "revenue_current", "REAL")
+	addColumnIfMissing("deals", "revenue_projected", "REAL")
+	addColumnIfMissing("deals", "profit_margin", "REAL")
+	addColumnIfMissing("deals", "patent_status", "TEXT")
+	addColumnIfMissing("deals", "created_at", "DATETIME")
+	addColumnIfMissing("deals", "updated_at", "DATETIME")
+
+	// Create founders table, any number per deal
+	_, err = db.Exec(`
+		CREATE TABLE IF NOT EXISTS founders (
+			id INTEGER PRIMARY KEY,
+			deal_id INTEGER REFERENCES deals(id),
+			name TEXT,
+			gender TEXT,
+			age_band TEXT,
+			repeat_founder BOOLEAN DEFAULT 0,
+			created_at DATETIME DEFAULT CURRENT_TIMESTAMP
+		)
+	`)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	// Create seasons table, summaries recomputed from deals after every import
+	_, err = db.Exec(`
+		CREATE TABLE IF NOT EXISTS seasons (
+			id INTEGER PRIMARY KEY,
+			season_number INTEGER UNIQUE NOT NULL,
+			start_date DATE,
+			end_date DATE,
+			total_episodes INTEGER,
+			total_pitches INTEGER,
+			total_deals INTEGER,
+			total_investment REAL,
+			average_valuation REAL,
+			median_valuation REAL,
+			success_rate REAL,
+			created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+			updated_at DATETIME DEFAULT CURRENT_TIMESTAMP
+		)
+	`)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	// Create episodes table, one row per aired episode
+	_, err = db.Exec(`
+		CREATE TABLE IF NOT EXISTS episodes (
+			id INTEGER PRIMARY KEY,
+			season INTEGER NOT NULL,
+			episode INTEGER NOT NULL,
+			title TEXT DEFAULT '',
+			air_date TEXT DEFAULT '',
+			created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+			updated_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+			UNIQUE(season, episode)
+		)
+	`)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	// Create reports table, saved analytics queries per user
+	_, err = db.Exec(`
+		CREATE TABLE IF NOT EXISTS reports (
+			id INTEGER PRIMARY KEY,
+			user_id INTEGER NOT NULL,
+			name TEXT NOT NULL,
+			definition TEXT NOT NULL,
+			chart_type TEXT,
+			schedule TEXT DEFAULT '',
+			share_token TEXT UNIQUE,
+			last_result TEXT,
+			last_run_at TEXT,
+			next_run_at TEXT,
+			created_at TEXT,
+			updated_at TEXT
+		)
+	`)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	// Create deal labels table, the classifier's suggestions for each deal
+	_, err = db.Exec(`
+		CREATE TABLE IF NOT EXISTS deal_labels (
+			id INTEGER PRIMARY KEY,
+			deal_id INTEGER UNIQUE REFERENCES deals(id),
+			suggested_industry TEXT,
+			industry_confidence REAL,
+			industry_mislabeled BOOLEAN,
+			industry_filled BOOLEAN DEFAULT 0,
+			suggested_category TEXT,
+			category_confidence REAL,
+			category_mislabeled BOOLEAN,
+			category_filled BOOLEAN DEFAULT 0,
+			created_at DATETIME DEFAULT CURRENT_TIMESTAMP
+		)
+	`)
+	if err != nil {
+		log.Fatal(err)
+	}
+	addColumnIfMissing("deal_labels", "industry_filled", "BOOLEAN DEFAULT 0")
+	addColumnIfMissing("deal_labels", "category_filled", "BOOLEAN DEFAULT 0")
+
+	// Create predictions table
+	_, err = db.Exec(`
+		CREATE TABLE IF NOT EXISTS predictions (
+			id INTEGER PRIMARY KEY,
+			deal_id INTEGER REFERENCES deals(id),
+			success_probability REAL,
+			predicted_equity REAL,
+			predicted_amount REAL,
+			predicted_valuation REAL,
+			valuation_low REAL,
+			valuation_high REAL,
+			risk_score INTEGER,
+			model_version TEXT,
+			created_at DATETIME DEFAULT CURRENT_TIMESTAMP
+		)
+	`)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	// Create backtests table, one row per season per model version
+	_, err = db.Exec(`
+		CREATE TABLE IF NOT EXISTS backtests (
+			id INTEGER PRIMARY KEY,
+			model_version TEXT NOT NULL,
+			run_at DATETIME,
+			season INTEGER,
+			training_size INTEGER,
+			deals INTEGER,
+			accuracy REAL,
+			brier_score REAL,
+			auc REAL,
+			calibration TEXT,
+			industries TEXT
+		)
+	`)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	// Create sharks table
+	_, err = db.Exec(`
+		CREATE TABLE IF NOT EXISTS sharks (
+			id TEXT PRIMARY KEY,
+			name TEXT NOT NULL,
+			title TEXT,
+			company TEXT,
+			bio TEXT,
+			profile_image TEXT,
+			successful_exits INTEGER DEFAULT 0,
+			industry_preference TEXT,
+			investment_range TEXT,
+			notable_investments TEXT,
+			investment_style TEXT,
+			expertise TEXT,
+			education TEXT,
+			achievements TEXT,
+			social_media TEXT,
+			investment_stats TEXT,
+			created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+			updated_at DATETIME DEFAULT CURRENT_TIMESTAMP
+		)
+	`)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	// List and nested profile fields are stored as JSON text
+	addColumnIfMissing("sharks", "successful_exits", "INTEGER DEFAULT 0")
+	for _, column := range []string{
+		"industry_preference", "investment_range", "notable_investments",
+		"investment_style", "expertise", "education", "achievements",
+		"social_media", "investment_stats",
+	} {
+		addColumnIfMissing("sharks", column, "TEXT")
+	}
+
+	// Create shark appearances table, one row per shark per episode
+	_, err = db.Exec(`
+		CREATE TABLE IF NOT EXISTS shark_appearances (
+			id INTEGER PRIMARY KEY,
+			shark_id TEXT NOT NULL REFERENCES sharks(id),
+			shark_name TEXT,
+			season INTEGER NOT NULL,
+			episode INTEGER NOT NULL,
+			guest BOOLEAN DEFAULT 0,
+			created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+			UNIQUE(shark_id, season, episode)
 		)
 	`)
 	if err != nil {
@@ -105,7 +468,35 @@ func createTables() {
 	}
 }
 
-func importExcelData() {
+// addColumnIfMissing adds a column to an existing table, since CREATE TABLE IF NOT EXISTS
+// leaves tables created by older versions untouched
+func addColumnIfMissing(table, column, definition string) {
+	rows, err := db.Query("SELECT name FROM pragma_table_info(?)", table)
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var name string
+		if err := rows.Scan(&name); err != nil {
+			log.Fatal(err)
+		}
+		if name == column {
+			return
+		}
+	}
+
+	if _, err := db.Exec("ALTER TABLE " + table + " ADD COLUMN " + column + " " + definition); err != nil {
+		log.Fatal(err)
+	}
+}
+
+// importExcelData imports the deals spreadsheet and returns the seasons that
+// were not in the database before
+func importExcelData() []int {
+	existing := storedSeasons()
+
 	// Open Excel file
 	xlFile, err := xlsx.OpenFile("data/deals.xlsx")
 	if err != nil {
@@ -120,20 +511,39 @@ func importExcelData() {
 		INSERT INTO deals (
 			season, episode, startup_name, industry, ask_amount,
 			ask_equity, valuation, deal_amount, deal_equity, deal_debt,
-			multiple_sharks, interested_sharks, invested_sharks, success_status
-		) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+			multiple_sharks, interested_sharks, invested_sharks, success_status,
+			pitch_description, product_category, location, city, state,
+			city_tier, team_size, founded_year, revenue_current,
+			revenue_projected, profit_margin, patent_status
+		) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
 	`)
 	if err != nil {
 		log.Fatal(err)
 	}
 	defer stmt.Close()
 
+	// Every episode with a pitch gets a row, with its air date when the sheet has one
+	episodeStmt, err := db.Prepare(`
+		INSERT INTO episodes (season, episode, air_date) VALUES (?, ?, ?)
+		ON CONFLICT(season, episode) DO UPDATE SET
+			air_date = CASE WHEN excluded.air_date != '' THEN excluded.air_date ELSE episodes.air_date END,
+			updated_at = CURRENT_TIMESTAMP
+	`)
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer episodeStmt.Close()
+
+	// Columns added after the original layout are found by their header, so
+	// older spreadsheets without them still import
+	columns := sheetColumns(sheet)
+
 	// Skip header row
 	for i := 1; i < sheet.MaxRow; i++ {
 		row := sheet.Row(i)
 		
 		// Convert Excel row to Deal struct
-		deal := Deal{
+		deal := models.Deal{
 			Season:          row.GetCell(0).Int(),
 			Episode:         row.GetCell(1).Int(),
 			StartupName:     row.GetCell(2).String(),
@@ -148,8 +558,21 @@ func importExcelData() {
 			InterestedSharks: strings.Split(row.GetCell(11).String(), ","),
 			InvestedSharks:  strings.Split(row.GetCell(12).String(), ","),
 			SuccessStatus:   row.GetCell(13).String(),
+			PitchDescription: optionalCell(row, columns, "pitch_description"),
+			ProductCategory: optionalCell(row, columns, "product_category"),
+			Location:        optionalCell(row, columns, "location"),
+			TeamSize:        optionalInt(row, columns, "team_size"),
+			FoundedYear:     optionalInt(row, columns, "founded_year"),
+			RevenueCurrent:  optionalNumber(row, columns, "revenue_current"),
+			RevenueProjected: optionalFloat(row, columns, "revenue_projected"),
+			ProfitMargin:    optionalNumber(row, columns, "profit_margin"),
+			PatentStatus:    optionalCell(row, columns, "patent_status"),
 		}
 
+		// Place the free-text location on the map
+		place := geo.Normalize(deal.Location)
+		deal.City, deal.State, deal.CityTier = place.City, place.State, place.Tier
+
 		// Insert into database
 		_, err = stmt.Exec(
 			deal.Season, deal.Episode, deal.StartupName, deal.Industry,
@@ -157,12 +580,104 @@ func importExcelData() {
 			deal.DealEquity, deal.DealDebt, deal.MultipleSharks,
 			strings.Join(deal.InterestedSharks, ","),
 			strings.Join(deal.InvestedSharks, ","),
-			deal.SuccessStatus,
+			deal.SuccessStatus, deal.PitchDescription, deal.ProductCategory,
+			deal.Location, deal.City, deal.State, deal.CityTier,
+			deal.TeamSize, deal.FoundedYear, deal.RevenueCurrent,
+			deal.RevenueProjected, deal.ProfitMargin, deal.PatentStatus,
 		)
 		if err != nil {
 			log.Printf("Error inserting row %d: %v", i, err)
 		}
+
+		airDate := optionalCell(row, columns, "air_date")
+		if airDate != "" {
+			if parsed, err := time.Parse("2006-01-02", airDate); err == nil {
+				airDate = parsed.Format("2006-01-02")
+			} else {
+				log.Printf("Ignoring invalid air date %q in row %d", airDate, i)
+				airDate = ""
+			}
+		}
+		if _, err := episodeStmt.Exec(deal.Season, deal.Episode, airDate); err != nil {
+			log.Printf("Error recording episode for row %d: %v", i, err)
+		}
+	}
+
+	var newSeasons []int
+	for season := range storedSeasons() {
+		if !existing[season] {
+			newSeasons = append(newSeasons, season)
+		}
+	}
+	sort.Ints(newSeasons)
+	return newSeasons
+}
+
+// sheetColumns maps each normalized header of the first row to its column
+func sheetColumns(sheet *xlsx.Sheet) map[string]int {
+	columns := make(map[string]int)
+	header := sheet.Row(0)
+	for col := 0; col < sheet.MaxCol; col++ {
+		name := strings.ToLower(strings.TrimSpace(header.GetCell(col).String()))
+		name = strings.Join(strings.Fields(name), "_")
+		if name != "" {
+			columns[name] = col
+		}
 	}
+	return columns
+}
+
+// optionalCell reads a column by header, empty when the sheet does not have it
+func optionalCell(row *xlsx.Row, columns map[string]int, name string) string {
+	col, ok := columns[name]
+	if !ok {
+		return ""
+	}
+	return strings.TrimSpace(row.GetCell(col).String())
+}
+
+// optionalInt reads a whole number column by header, 0 when missing or invalid
+func optionalInt(row *xlsx.Row, columns map[string]int, name string) int {
+	return int(optionalFloat(row, columns, name))
+}
+
+// optionalFloat reads a number column by header, 0 when missing or invalid
+func optionalFloat(row *xlsx.Row, columns map[string]int, name string) float64 {
+	value, err := strconv.ParseFloat(optionalCell(row, columns, name), 64)
+	if err != nil {
+		return 0
+	}
+	return value
+}
+
+// optionalNumber reads a number column by header, nil when missing or invalid
+// so that an unreported figure is stored as NULL rather than 0
+func optionalNumber(row *xlsx.Row, columns map[string]int, name string) *float64 {
+	value, err := strconv.ParseFloat(optionalCell(row, columns, name), 64)
+	if err != nil {
+		return nil
+	}
+	return &value
+}
+
+// storedSeasons returns the seasons that have deals in the database
+func storedSeasons() map[int]bool {
+	seasons := make(map[int]bool)
+
+	rows, err := db.Query(`SELECT DISTINCT season FROM deals`)
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var season int
+		if err := rows.Scan(&season); err != nil {
+			log.Fatal(err)
+		}
+		seasons[season] = true
+	}
+	return seasons
 }
 
 func cors() gin.HandlerFunc {
@@ -304,26 +819,75 @@ func authMiddleware() gin.HandlerFunc {
 	}
 }
 
+// requireEditor only lets through users with the editor role; it must run after authMiddleware
+func requireEditor() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		userID, _ := c.Get("user_id")
+
+		var role sql.NullString
+		err := db.QueryRow(`SELECT role FROM users WHERE rowid = ?`, userID).Scan(&role)
+		if err != nil || role.String != "editor" {
+			c.JSON(http.StatusForbidden, gin.H{"error": "Editor access required"})
+			c.Abort()
+			return
+		}
+
+		c.Next()
+	}
+}
+
 func getDeals(c *gin.Context) {
 	// Get deals from database
-	rows, err := db.Query("SELECT * FROM deals")
+	deals, err := loadDeals("")
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
+
+	c.JSON(http.StatusOK, deals)
+}
+
+// loadDeals reads deals from the database, optionally restricted by a WHERE clause.
+// Columns are listed explicitly so that adding columns never shifts the scan.
+func loadDeals(where string, args ...interface{}) ([]models.Deal, error) {
+	query := `
+		SELECT
+			id, season, episode, startup_name, industry, ask_amount,
+			ask_equity, valuation, deal_amount, deal_equity, deal_debt,
+			multiple_sharks, interested_sharks, invested_sharks, success_status,
+			COALESCE(post_show_status, ''), COALESCE(pitch_description, ''),
+			COALESCE(product_category, ''), COALESCE(location, ''),
+			COALESCE(city, ''), COALESCE(state, ''), COALESCE(city_tier, 0),
+			COALESCE(team_size, 0), COALESCE(founded_year, 0),
+			revenue_current, COALESCE(revenue_projected, 0),
+			profit_margin, COALESCE(patent_status, '')
+		FROM deals
+	`
+	if where != "" {
+		query += " WHERE " + where
+	}
+
+	rows, err := db.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
 	defer rows.Close()
 
-	var deals []Deal
+	var deals []models.Deal
 	for rows.Next() {
-		var deal Deal
-		var interestedSharksStr, investedSharksStr string
+		var deal models.Deal
+		var interestedSharksStr, investedSharksStr, postShowStr string
 		
 		err := rows.Scan(
 			&deal.ID, &deal.Season, &deal.Episode, &deal.StartupName,
 			&deal.Industry, &deal.AskAmount, &deal.AskEquity, &deal.Valuation,
 			&deal.DealAmount, &deal.DealEquity, &deal.DealDebt,
 			&deal.MultipleSharks, &interestedSharksStr, &investedSharksStr,
-			&deal.SuccessStatus,
+			&deal.SuccessStatus, &postShowStr, &deal.PitchDescription,
+			&deal.ProductCategory, &deal.Location, &deal.City, &deal.State,
+			&deal.CityTier, &deal.TeamSize, &deal.FoundedYear,
+			&deal.RevenueCurrent, &deal.RevenueProjected, &deal.ProfitMargin,
+			&deal.PatentStatus,
 		)
 		if err != nil {
 			log.Printf("Error scanning row: %v", err)
@@ -332,10 +896,56 @@ func getDeals(c *gin.Context) {
 
 		deal.InterestedSharks = strings.Split(interestedSharksStr, ",")
 		deal.InvestedSharks = strings.Split(investedSharksStr, ",")
+		if postShowStr != "" {
+			if err := json.Unmarshal([]byte(postShowStr), &deal.PostShowStatus); err != nil {
+				log.Printf("Error decoding post show status for deal %d: %v", deal.ID, err)
+			}
+		}
 		deals = append(deals, deal)
 	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
 
-	c.JSON(http.StatusOK, deals)
+	if err := attachFounders(deals); err != nil {
+		return nil, err
+	}
+	if err := attachPanels(deals); err != nil {
+		return nil, err
+	}
+	return deals, attachAirDates(deals)
+}
+
+// attachFounders loads the founders of each deal
+func attachFounders(deals []models.Deal) error {
+	rows, err := db.Query(`
+		SELECT id, deal_id, COALESCE(name, ''), COALESCE(gender, ''),
+			COALESCE(age_band, ''), COALESCE(repeat_founder, 0)
+		FROM founders
+		ORDER BY id
+	`)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	byDeal := make(map[uint][]models.Founder)
+	for rows.Next() {
+		var founder models.Founder
+		err := rows.Scan(
+			&founder.ID, &founder.DealID, &founder.Name, &founder.Gender,
+			&founder.AgeBand, &founder.RepeatFounder,
+		)
+		if err != nil {
+			return err
+		}
+		byDeal[founder.DealID] = append(byDeal[founder.DealID], founder)
+	}
+
+	for i := range deals {
+		deals[i].Founders = byDeal[deals[i].ID]
+	}
+	return rows.Err()
 }
 
 func getSharks(c *gin.Context) {
@@ -381,35 +991,16 @@ func getSharks(c *gin.Context) {
 	c.JSON(http.StatusOK, sharks)
 }
 
-func getAnalytics(c *gin.Context) {
-	// Get overall analytics
-	var totalDeals int
-	var totalInvestment float64
-	var avgValuation float64
-	var successRate float64
-
-	err := db.QueryRow(`
-		SELECT 
-			COUNT(*) as total_deals,
-			SUM(deal_amount) as total_investment,
-			AVG(valuation) as avg_valuation,
-			(SELECT CAST(COUNT(*) AS FLOAT) / (SELECT COUNT(*) FROM deals) 
-			 FROM deals WHERE success_status = 'funded') as success_rate
-		FROM deals
-	`).Scan(&totalDeals, &totalInvestment, &avgValuation, &successRate)
-
-	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
-		return
-	}
-
-	// Get industry breakdown
+func getPredictions(c *gin.Context) {
+	// Get data for ML predictions
 	rows, err := db.Query(`
 		SELECT 
 			industry,
-			COUNT(*) as count,
-			AVG(valuation) as avg_valuation,
-			SUM(deal_amount) as total_investment
+			COALESCE(AVG(CASE WHEN deal_amount > 0 THEN deal_amount END), 0) as avg_deal,
+			COALESCE(AVG(valuation), 0) as avg_valuation,
+			COUNT(*) as deal_count,
+			AVG(CASE WHEN success_status = 'funded' THEN 1.0 ELSE 0.0 END) as success_rate,
+			SUM(CASE WHEN success_status = 'funded' THEN 1 ELSE 0 END) as funded
 		FROM deals
 		GROUP BY industry
 	`)
@@ -419,58 +1010,19 @@ func getAnalytics(c *gin.Context) {
 	}
 	defer rows.Close()
 
-	industries := make([]gin.H, 0)
-	for rows.Next() {
-		var industry string
-		var count int
-		var avgVal, totalInv float64
-		
-		err := rows.Scan(&industry, &count, &avgVal, &totalInv)
-		if err != nil {
-			log.Printf("Error scanning row: %v", err)
-			continue
-		}
-
-		industries = append(industries, gin.H{
-			"industry": industry,
-			"count": count,
-			"avg_valuation": avgVal,
-			"total_investment": totalInv,
-		})
-	}
-
-	c.JSON(http.StatusOK, gin.H{
-		"overall": gin.H{
-			"total_deals": totalDeals,
-			"total_investment": totalInvestment,
-			"avg_valuation": avgValuation,
-			"success_rate": successRate,
-		},
-		"industries": industries,
-	})
-}
-
-func getPredictions(c *gin.Context) {
-	// Get data for ML predictions
-	rows, err := db.Query(`
-		SELECT 
-			industry,
-			AVG(deal_amount) as avg_deal,
-			AVG(valuation) as avg_valuation,
-			COUNT(*) as deal_count,
-			(SELECT CAST(COUNT(*) AS FLOAT) / COUNT(*) 
-			 FROM deals d2 
-			 WHERE d2.industry = d1.industry 
-			 AND d2.success_status = 'funded'
-			) as success_rate
-		FROM deals d1
-		GROUP BY industry
-	`)
+	// Risk is scored per deal and averaged per industry
+	deals, err := loadDeals("")
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
-	defer rows.Close()
+	industryRisk := make(map[string]*riskSummary)
+	for _, deal := range deals {
+		if industryRisk[deal.Industry] == nil {
+			industryRisk[deal.Industry] = &riskSummary{}
+		}
+		industryRisk[deal.Industry].add(risk.Score(deal, handlers.RiskConfig))
+	}
 
 	predictions := make([]gin.H, 0)
 	for rows.Next() {
@@ -478,22 +1030,29 @@ func getPredictions(c *gin.Context) {
 		var avgDeal, avgValuation float64
 		var dealCount int
 		var successRate float64
+		var funded int
 		
-		err := rows.Scan(&industry, &avgDeal, &avgValuation, &dealCount, &successRate)
+		err := rows.Scan(&industry, &avgDeal, &avgValuation, &dealCount, &successRate, &funded)
 		if err != nil {
 			log.Printf("Error scanning row: %v", err)
 			continue
 		}
 
-		// Simple prediction model based on historical data
-		growthPotential := (successRate * avgValuation) / avgDeal
-		riskScore := 1 - successRate
+		// Simple prediction model based on historical data, industries
+		// without a funded deal have no growth potential to speak of
+		growthPotential := 0.0
+		if avgDeal > 0 {
+			growthPotential = (successRate * avgValuation) / avgDeal
+		}
+		summary := industryRisk[industry]
 
 		predictions = append(predictions, gin.H{
 			"industry": industry,
 			"success_probability": successRate,
+			"success_interval": stats.NewRate(funded, dealCount),
 			"growth_potential": growthPotential,
-			"risk_score": riskScore,
+			"risk_score": summary.score(),
+			"risk_components": summary.components(),
 			"market_data": gin.H{
 				"avg_deal": avgDeal,
 				"avg_valuation": avgValuation,
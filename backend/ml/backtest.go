@@ -0,0 +1,137 @@
+package ml
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/your-username/shark-tank-analytics/models"
+)
+
+// SeasonBacktest scores a model trained on earlier seasons against one season
+type SeasonBacktest struct {
+	Season       int                `json:"season"`
+	TrainingSize int                `json:"training_size"`
+	Deals        int                `json:"deals"`
+	Accuracy     float64            `json:"accuracy"`
+	BrierScore   float64            `json:"brier_score"`
+	AUC          float64            `json:"auc"`
+	Calibration  []CalibrationBin   `json:"calibration"`
+	Industries   []IndustryBacktest `json:"industries"`
+}
+
+// IndustryBacktest scores the predictions for one industry within a season
+type IndustryBacktest struct {
+	Industry      string  `json:"industry"`
+	Deals         int     `json:"deals"`
+	Accuracy      float64 `json:"accuracy"`
+	BrierScore    float64 `json:"brier_score"`
+	MeanPredicted float64 `json:"mean_predicted"`
+	ObservedRate  float64 `json:"observed_rate"`
+}
+
+// Backtest trains on seasons 1..N-1 and predicts season N for every season
+// that has enough earlier deals to train on, so each season is scored the way
+// it would have been before it aired
+func Backtest(deals []models.Deal, opts Options) ([]SeasonBacktest, error) {
+	bySeason := make(map[int][]models.Deal)
+	for _, deal := range deals {
+		bySeason[deal.Season] = append(bySeason[deal.Season], deal)
+	}
+
+	seasons := make([]int, 0, len(bySeason))
+	for season := range bySeason {
+		seasons = append(seasons, season)
+	}
+	sort.Ints(seasons)
+
+	// Intervals are not scored
+	opts.BootstrapSamples = 0
+
+	results := make([]SeasonBacktest, 0)
+	var history []models.Deal
+	for _, season := range seasons {
+		test := bySeason[season]
+
+		model, err := Train(history, opts)
+		history = append(history, test...)
+		if err == ErrNotEnoughData {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		scores := make([]float64, len(test))
+		labels := make([]bool, len(test))
+		for i, deal := range test {
+			scores[i] = model.Predict(deal).DealProbability
+			labels[i] = Funded(deal)
+		}
+
+		results = append(results, SeasonBacktest{
+			Season:       season,
+			TrainingSize: model.TrainingSize,
+			Deals:        len(test),
+			Accuracy:     accuracy(scores, labels),
+			BrierScore:   BrierScore(scores, labels),
+			AUC:          AUC(scores, labels),
+			Calibration:  Calibration(scores, labels, calibrationBins),
+			Industries:   industryBacktests(test, scores, labels),
+		})
+	}
+
+	if len(results) == 0 {
+		return nil, ErrNotEnoughData
+	}
+	return results, nil
+}
+
+func industryBacktests(deals []models.Deal, scores []float64, labels []bool) []IndustryBacktest {
+	type group struct {
+		name   string
+		scores []float64
+		labels []bool
+	}
+
+	groups := make(map[string]*group)
+	for i, deal := range deals {
+		key := normalizeCategory(deal.Industry)
+		if groups[key] == nil {
+			groups[key] = &group{name: strings.TrimSpace(deal.Industry)}
+		}
+		groups[key].scores = append(groups[key].scores, scores[i])
+		groups[key].labels = append(groups[key].labels, labels[i])
+	}
+
+	results := make([]IndustryBacktest, 0, len(groups))
+	for _, g := range groups {
+		meanPredicted, observed := 0.0, 0.0
+		for i, p := range g.scores {
+			meanPredicted += p
+			observed += indicator(g.labels[i])
+		}
+		n := float64(len(g.scores))
+
+		results = append(results, IndustryBacktest{
+			Industry:      g.name,
+			Deals:         len(g.scores),
+			Accuracy:      accuracy(g.scores, g.labels),
+			BrierScore:    BrierScore(g.scores, g.labels),
+			MeanPredicted: meanPredicted / n,
+			ObservedRate:  observed / n,
+		})
+	}
+
+	sort.Slice(results, func(i, j int) bool {
+		return results[i].Deals > results[j].Deals
+	})
+	return results
+}
+
+func accuracy(scores []float64, labels []bool) float64 {
+	if len(scores) == 0 {
+		return 0
+	}
+	c := ConfusionAt(scores, labels, 0.5)
+	return float64(c.TruePositives+c.TrueNegatives) / float64(len(scores))
+}
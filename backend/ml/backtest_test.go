@@ -0,0 +1,40 @@
+package ml
+
+import "testing"
+
+func TestBacktest(t *testing.T) {
+	if _, err := Backtest(syntheticDeals(12), DefaultOptions); err != ErrNotEnoughData {
+		t.Errorf("Backtest of three seasons of 4 deals: error = %v, want ErrNotEnoughData", err)
+	}
+
+	results, err := Backtest(syntheticDeals(60), DefaultOptions)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Season 1 has nothing earlier to train on
+	want := []struct{ season, training int }{{2, 20}, {3, 40}}
+	if len(results) != len(want) {
+		t.Fatalf("got %d seasons, want %d", len(results), len(want))
+	}
+	for i, r := range results {
+		if r.Season != want[i].season || r.TrainingSize != want[i].training || r.Deals != 20 {
+			t.Errorf("result %d is season %d trained on %d deals scoring %d, want season %d trained on %d scoring 20",
+				i, r.Season, r.TrainingSize, r.Deals, want[i].season, want[i].training)
+		}
+		if r.AUC < 0.7 {
+			t.Errorf("season %d AUC = %v, want at least 0.7", r.Season, r.AUC)
+		}
+
+		counted := 0
+		for j, industry := range r.Industries {
+			counted += industry.Deals
+			if j > 0 && industry.Deals > r.Industries[j-1].Deals {
+				t.Errorf("season %d industries are not ordered by deals: %+v", r.Season, r.Industries)
+			}
+		}
+		if counted != r.Deals {
+			t.Errorf("season %d industries hold %d deals, want %d", r.Season, counted, r.Deals)
+		}
+	}
+}
@@ -0,0 +1,139 @@
+package ml
+
+import (
+	"strings"
+
+	"github.com/your-username/shark-tank-analytics/models"
+)
+
+// MislabelConfidence is how sure the classifier must be of a different label
+// before a row is flagged as likely mislabeled
+const MislabelConfidence = 0.8
+
+// FillConfidence is the default confidence the classifier needs before its
+// suggestion fills in a missing label
+const FillConfidence = 0.9
+
+// Categorizer suggests an industry and product category from a pitch's text
+type Categorizer struct {
+	Industry *NaiveBayes `json:"industry"`
+	Category *NaiveBayes `json:"category"`
+	// Canonical spelling of each label, keyed by its normalized form
+	Industries map[string]string `json:"industries"`
+	Categories map[string]string `json:"categories"`
+}
+
+// Suggestion is the classifier's view of a deal's labels
+type Suggestion struct {
+	Industry           string  `json:"industry"`
+	IndustryConfidence float64 `json:"industry_confidence"`
+	Category           string  `json:"category"`
+	CategoryConfidence float64 `json:"category_confidence"`
+	// Set when the suggestion confidently disagrees with a label the deal has
+	IndustryMislabeled bool `json:"industry_mislabeled"`
+	CategoryMislabeled bool `json:"category_mislabeled"`
+}
+
+// TrainCategorizer trains on every deal that has text and labels. Labels that
+// only differ in case or spacing are merged under their most common spelling.
+func TrainCategorizer(deals []models.Deal) *Categorizer {
+	c := &Categorizer{
+		Industry:   NewNaiveBayes(),
+		Category:   NewNaiveBayes(),
+		Industries: canonicalLabels(deals, func(d models.Deal) string { return d.Industry }),
+		Categories: canonicalLabels(deals, func(d models.Deal) string { return d.ProductCategory }),
+	}
+
+	for _, deal := range deals {
+		tokens := Tokenize(PitchText(deal))
+		if len(tokens) == 0 {
+			continue
+		}
+		if label := normalizeCategory(deal.Industry); label != "" {
+			c.Industry.Add(tokens, label)
+		}
+		if label := normalizeCategory(deal.ProductCategory); label != "" {
+			c.Category.Add(tokens, label)
+		}
+	}
+
+	return c
+}
+
+// PitchText is the text the categorizer reads: the startup name and its pitch
+func PitchText(deal models.Deal) string {
+	return strings.TrimSpace(deal.StartupName + " " + deal.PitchDescription)
+}
+
+// Suggest labels a pitch the categorizer was not trained on
+func (c *Categorizer) Suggest(deal models.Deal) Suggestion {
+	tokens := Tokenize(PitchText(deal))
+	s := Suggestion{}
+	s.Industry, s.IndustryConfidence = best(c.Industry.Classify(tokens), c.Industries, tokens)
+	s.Category, s.CategoryConfidence = best(c.Category.Classify(tokens), c.Categories, tokens)
+	return s
+}
+
+// Review labels a stored deal, scoring it with its own row left out of training
+// so that a mislabeled row cannot vouch for itself
+func (c *Categorizer) Review(deal models.Deal) Suggestion {
+	tokens := Tokenize(PitchText(deal))
+	industry := normalizeCategory(deal.Industry)
+	category := normalizeCategory(deal.ProductCategory)
+
+	industryScores := c.Industry.Classify(tokens)
+	if industry != "" && len(tokens) > 0 {
+		industryScores = c.Industry.ClassifyHeldOut(tokens, industry)
+	}
+	categoryScores := c.Category.Classify(tokens)
+	if category != "" && len(tokens) > 0 {
+		categoryScores = c.Category.ClassifyHeldOut(tokens, category)
+	}
+
+	s := Suggestion{}
+	s.Industry, s.IndustryConfidence = best(industryScores, c.Industries, tokens)
+	s.Category, s.CategoryConfidence = best(categoryScores, c.Categories, tokens)
+
+	s.IndustryMislabeled = industry != "" && s.Industry != "" &&
+		normalizeCategory(s.Industry) != industry && s.IndustryConfidence >= MislabelConfidence
+	s.CategoryMislabeled = category != "" && s.Category != "" &&
+		normalizeCategory(s.Category) != category && s.CategoryConfidence >= MislabelConfidence
+
+	return s
+}
+
+// best picks the most likely class. Without any words to go on the prior alone
+// would decide, so no suggestion is made.
+func best(scores []ClassScore, canonical map[string]string, tokens []string) (string, float64) {
+	if len(scores) == 0 || len(tokens) == 0 {
+		return "", 0
+	}
+	return canonical[scores[0].Class], scores[0].Probability
+}
+
+// canonicalLabels maps each normalized label to its most common spelling
+func canonicalLabels(deals []models.Deal, label func(models.Deal) string) map[string]string {
+	spellings := make(map[string]map[string]int)
+	for _, deal := range deals {
+		spelling := strings.TrimSpace(label(deal))
+		key := normalizeCategory(spelling)
+		if key == "" {
+			continue
+		}
+		if spellings[key] == nil {
+			spellings[key] = make(map[string]int)
+		}
+		spellings[key][spelling]++
+	}
+
+	canonical := make(map[string]string, len(spellings))
+	for key, counts := range spellings {
+		bestCount := 0
+		for spelling, count := range counts {
+			if count > bestCount || (count == bestCount && spelling < canonical[key]) {
+				canonical[key], bestCount = spelling, count
+			}
+		}
+	}
+	return canonical
+}
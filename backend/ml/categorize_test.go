@@ -0,0 +1,94 @@
+package ml
+
+import (
+	"testing"
+
+	"github.com/your-username/shark-tank-analytics/models"
+)
+
+func TestTokenize(t *testing.T) {
+	got := Tokenize("The Skippi Ice-Pops Pvt. Ltd: 100% natural, for kids & 2 moms!")
+	want := []string{"skippi", "ice", "pops", "natural", "kids", "moms"}
+	if len(got) != len(want) {
+		t.Fatalf("Tokenize = %v, want %v", got, want)
+	}
+	for i := range got {
+		if got[i] != want[i] {
+			t.Fatalf("Tokenize = %v, want %v", got, want)
+		}
+	}
+}
+
+// labeledPitches returns food and beauty pitches with distinct vocabularies
+func labeledPitches() []models.Deal {
+	food := []string{
+		"healthy snacks and millet cookies", "organic snacks with millet", "spicy snacks and namkeen",
+		"millet cookies for breakfast", "protein snacks and cookies", "sugar free cookies and snacks",
+	}
+	beauty := []string{
+		"natural skincare serum", "herbal skincare and face serum", "vegan lipstick and skincare",
+		"face serum for oily skin", "ayurvedic skincare cream", "skincare for sensitive skin",
+	}
+
+	var deals []models.Deal
+	for _, pitch := range food {
+		deals = append(deals, models.Deal{PitchDescription: pitch, Industry: "Food", ProductCategory: "Snacks"})
+	}
+	for _, pitch := range beauty {
+		deals = append(deals, models.Deal{PitchDescription: pitch, Industry: "Beauty", ProductCategory: "Skincare"})
+	}
+	return deals
+}
+
+func TestCategorizerSuggest(t *testing.T) {
+	deals := labeledPitches()
+	// A differently spelled label is merged under the most common spelling
+	deals[0].Industry = " food "
+	c := TrainCategorizer(deals)
+
+	tests := []struct {
+		pitch        string
+		wantIndustry string
+		wantCategory string
+	}{
+		{"crunchy millet snacks", "Food", "Snacks"},
+		{"skincare serum for dry skin", "Beauty", "Skincare"},
+		{"", "", ""},
+	}
+
+	for _, tt := range tests {
+		s := c.Suggest(models.Deal{PitchDescription: tt.pitch})
+		if s.Industry != tt.wantIndustry || s.Category != tt.wantCategory {
+			t.Errorf("Suggest(%q) = %s/%s, want %s/%s", tt.pitch, s.Industry, s.Category, tt.wantIndustry, tt.wantCategory)
+		}
+		if tt.wantIndustry != "" && s.IndustryConfidence <= 0.5 {
+			t.Errorf("Suggest(%q) industry confidence = %v, want above one half", tt.pitch, s.IndustryConfidence)
+		}
+	}
+}
+
+func TestCategorizerReview(t *testing.T) {
+	deals := labeledPitches()
+	// A skincare pitch filed under food
+	mislabeled := models.Deal{PitchDescription: "skincare serum for glowing skin", Industry: "Food", ProductCategory: "Skincare"}
+	deals = append(deals, mislabeled)
+	c := TrainCategorizer(deals)
+
+	s := c.Review(mislabeled)
+	if !s.IndustryMislabeled || s.Industry != "Beauty" {
+		t.Errorf("Review = %+v, want the industry flagged with Beauty suggested", s)
+	}
+	if s.CategoryMislabeled {
+		t.Errorf("Review = %+v, want the matching category left alone", s)
+	}
+
+	// A correctly labeled row is not flagged
+	if s := c.Review(deals[1]); s.IndustryMislabeled || s.CategoryMislabeled {
+		t.Errorf("Review of a correct label = %+v, want nothing flagged", s)
+	}
+
+	// A deal without labels only gets suggestions
+	if s := c.Review(models.Deal{PitchDescription: "millet snacks"}); s.IndustryMislabeled || s.Industry != "Food" {
+		t.Errorf("Review of an unlabeled deal = %+v, want a Food suggestion without a flag", s)
+	}
+}
@@ -0,0 +1,140 @@
+package ml
+
+import (
+	"math"
+	"sort"
+
+	"github.com/your-username/shark-tank-analytics/models"
+	"github.com/your-username/shark-tank-analytics/stats"
+)
+
+// PSI above this is conventionally treated as a significant shift
+const driftThreshold = 0.25
+
+// Number of baseline quantile bins used to compute PSI
+const driftBins = 10
+
+// DriftReport compares a batch of new deals, typically a newly imported
+// season, against the deals the model has already seen
+type DriftReport struct {
+	BaselineDeals          int            `json:"baseline_deals"`
+	CurrentDeals           int            `json:"current_deals"`
+	Features               []FeatureDrift `json:"features"`
+	PredictionPSI          float64        `json:"prediction_psi"`
+	BaselineMeanPrediction float64        `json:"baseline_mean_prediction"`
+	CurrentMeanPrediction  float64        `json:"current_mean_prediction"`
+	BaselineObservedRate   float64        `json:"baseline_observed_rate"`
+	CurrentObservedRate    float64        `json:"current_observed_rate"`
+	Flagged                []string       `json:"flagged"`
+}
+
+// FeatureDrift is the shift of one numeric feature between baseline and current deals
+type FeatureDrift struct {
+	Feature      string  `json:"feature"`
+	BaselineMean float64 `json:"baseline_mean"`
+	CurrentMean  float64 `json:"current_mean"`
+	PSI          float64 `json:"psi"`
+}
+
+// Drift measures how far the current deals have moved from the baseline using
+// the population stability index (PSI) of each numeric feature and of the
+// predicted deal probability. Features and predictions with a PSI above 0.25
+// are flagged.
+func Drift(m *Model, baseline, current []models.Deal) DriftReport {
+	report := DriftReport{
+		BaselineDeals: len(baseline),
+		CurrentDeals:  len(current),
+		Flagged:       make([]string, 0),
+	}
+	if len(baseline) == 0 || len(current) == 0 {
+		return report
+	}
+
+	rawBaseline := make([][]float64, len(baseline))
+	for i, deal := range baseline {
+		rawBaseline[i] = m.Encoder.Raw(deal)
+	}
+	rawCurrent := make([][]float64, len(current))
+	for i, deal := range current {
+		rawCurrent[i] = m.Encoder.Raw(deal)
+	}
+
+	for j, name := range numericFeatures {
+		base := make([]float64, len(baseline))
+		for i, x := range rawBaseline {
+			base[i] = x[j]
+		}
+		cur := make([]float64, len(current))
+		for i, x := range rawCurrent {
+			cur[i] = x[j]
+		}
+
+		drift := FeatureDrift{
+			Feature:      name,
+			BaselineMean: stats.Mean(base),
+			CurrentMean:  stats.Mean(cur),
+			PSI:          PSI(base, cur),
+		}
+		if drift.PSI > driftThreshold {
+			report.Flagged = append(report.Flagged, name)
+		}
+		report.Features = append(report.Features, drift)
+	}
+
+	base := make([]float64, len(baseline))
+	for i, deal := range baseline {
+		base[i] = m.Predict(deal).DealProbability
+		report.BaselineObservedRate += indicator(Funded(deal))
+	}
+	cur := make([]float64, len(current))
+	for i, deal := range current {
+		cur[i] = m.Predict(deal).DealProbability
+		report.CurrentObservedRate += indicator(Funded(deal))
+	}
+	report.BaselineObservedRate /= float64(len(baseline))
+	report.CurrentObservedRate /= float64(len(current))
+	report.BaselineMeanPrediction = stats.Mean(base)
+	report.CurrentMeanPrediction = stats.Mean(cur)
+	report.PredictionPSI = PSI(base, cur)
+	if report.PredictionPSI > driftThreshold {
+		report.Flagged = append(report.Flagged, "prediction")
+	}
+
+	return report
+}
+
+// PSI returns the population stability index of current against baseline,
+// binned at the baseline deciles
+func PSI(baseline, current []float64) float64 {
+	if len(baseline) == 0 || len(current) == 0 {
+		return 0
+	}
+
+	// Bin edges at the baseline quantiles, deduplicated for discrete features
+	edges := make([]float64, 0, driftBins-1)
+	for b := 1; b < driftBins; b++ {
+		edge := stats.Quantile(baseline, float64(b)/driftBins)
+		if len(edges) == 0 || edge > edges[len(edges)-1] {
+			edges = append(edges, edge)
+		}
+	}
+
+	share := func(values []float64) []float64 {
+		counts := make([]float64, len(edges)+1)
+		for _, v := range values {
+			counts[sort.SearchFloat64s(edges, v)]++
+		}
+		for i := range counts {
+			// Smooth empty bins so the log term stays finite
+			counts[i] = (counts[i] + 0.5) / (float64(len(values)) + 0.5*float64(len(counts)))
+		}
+		return counts
+	}
+
+	expected, actual := share(baseline), share(current)
+	psi := 0.0
+	for i := range expected {
+		psi += (actual[i] - expected[i]) * math.Log(actual[i]/expected[i])
+	}
+	return psi
+}
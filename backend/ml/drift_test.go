@@ -0,0 +1,63 @@
+package ml
+
+import (
+	"math"
+	"testing"
+)
+
+func TestPSI(t *testing.T) {
+	uniform := make([]float64, 100)
+	shifted := make([]float64, 100)
+	for i := range uniform {
+		uniform[i] = float64(i)
+		shifted[i] = float64(i) + 50
+	}
+
+	tests := []struct {
+		name              string
+		baseline, current []float64
+		wantLow, wantHigh float64
+	}{
+		{"no baseline", nil, uniform, 0, 0},
+		{"same distribution", uniform, uniform, 0, 1e-12},
+		{"shifted by half the range", uniform, shifted, driftThreshold, math.Inf(1)},
+		{"constant feature", []float64{1, 1, 1, 1}, []float64{1, 1, 1, 1}, 0, 1e-12},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := PSI(tt.baseline, tt.current); got < tt.wantLow || got > tt.wantHigh {
+				t.Errorf("PSI = %v, want within [%v, %v]", got, tt.wantLow, tt.wantHigh)
+			}
+		})
+	}
+}
+
+func TestDrift(t *testing.T) {
+	deals := syntheticDeals(40)
+	m, err := Train(deals, DefaultOptions)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if report := Drift(m, deals, deals); len(report.Flagged) != 0 {
+		t.Errorf("the training deals drifted from themselves: %v", report.Flagged)
+	}
+
+	// A season asking ten times as much
+	current := syntheticDeals(20)
+	for i := range current {
+		current[i].AskAmount *= 10
+	}
+	report := Drift(m, deals, current)
+	if report.BaselineDeals != 40 || report.CurrentDeals != 20 {
+		t.Errorf("report compares %d with %d deals, want 40 with 20", report.BaselineDeals, report.CurrentDeals)
+	}
+	flagged := make(map[string]bool)
+	for _, name := range report.Flagged {
+		flagged[name] = true
+	}
+	if !flagged["log_ask_amount"] || flagged["has_revenue"] {
+		t.Errorf("flagged %v, want log_ask_amount and not has_revenue", report.Flagged)
+	}
+}
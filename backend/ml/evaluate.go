@@ -0,0 +1,223 @@
+package ml
+
+import (
+	"math"
+	"math/rand"
+	"sort"
+
+	"github.com/your-username/shark-tank-analytics/models"
+)
+
+// Number of equal width probability bins in the calibration table
+const calibrationBins = 10
+
+// Report summarizes how well the deal probability predicts outcomes on deals
+// held out of training
+type Report struct {
+	Version     string           `json:"version"`
+	Deals       int              `json:"deals"`
+	Folds       int              `json:"folds"`
+	AUC         float64          `json:"auc"`
+	FoldAUC     []float64        `json:"fold_auc"`
+	Accuracy    float64          `json:"accuracy"`
+	BrierScore  float64          `json:"brier_score"`
+	LogLoss     float64          `json:"log_loss"`
+	Calibration []CalibrationBin `json:"calibration"`
+	Confusion   Confusion        `json:"confusion_matrix"`
+}
+
+// CalibrationBin compares the mean predicted probability of the deals in a
+// probability range with the share of them that actually got a deal
+type CalibrationBin struct {
+	Lower         float64 `json:"lower"`
+	Upper         float64 `json:"upper"`
+	Count         int     `json:"count"`
+	MeanPredicted float64 `json:"mean_predicted"`
+	ObservedRate  float64 `json:"observed_rate"`
+}
+
+// Confusion is the confusion matrix at a probability threshold
+type Confusion struct {
+	Threshold      float64 `json:"threshold"`
+	TruePositives  int     `json:"true_positives"`
+	FalsePositives int     `json:"false_positives"`
+	TrueNegatives  int     `json:"true_negatives"`
+	FalseNegatives int     `json:"false_negatives"`
+}
+
+// CrossValidate runs k-fold cross-validation and reports metrics over the
+// out-of-fold predictions of every deal
+func CrossValidate(deals []models.Deal, k int, opts Options) (Report, error) {
+	if k < 2 || len(deals) < 2*k {
+		return Report{}, ErrNotEnoughData
+	}
+
+	// Shuffle once so folds do not follow season order
+	rng := rand.New(rand.NewSource(opts.Seed))
+	order := rng.Perm(len(deals))
+
+	// Intervals are not needed to score folds
+	foldOpts := opts
+	foldOpts.BootstrapSamples = 0
+
+	scores := make([]float64, len(deals))
+	labels := make([]bool, len(deals))
+	report := Report{Deals: len(deals), Folds: k}
+
+	for fold := 0; fold < k; fold++ {
+		var train, test []models.Deal
+		var testIndex []int
+		for i, idx := range order {
+			if i%k == fold {
+				test = append(test, deals[idx])
+				testIndex = append(testIndex, idx)
+			} else {
+				train = append(train, deals[idx])
+			}
+		}
+
+		model, err := Train(train, foldOpts)
+		if err != nil {
+			return Report{}, err
+		}
+
+		foldScores := make([]float64, len(test))
+		foldLabels := make([]bool, len(test))
+		for i, deal := range test {
+			foldScores[i] = model.Predict(deal).DealProbability
+			foldLabels[i] = Funded(deal)
+			scores[testIndex[i]] = foldScores[i]
+			labels[testIndex[i]] = foldLabels[i]
+		}
+		report.FoldAUC = append(report.FoldAUC, AUC(foldScores, foldLabels))
+	}
+
+	report.AUC = AUC(scores, labels)
+	report.BrierScore = BrierScore(scores, labels)
+	report.LogLoss = LogLoss(scores, labels)
+	report.Calibration = Calibration(scores, labels, calibrationBins)
+	report.Confusion = ConfusionAt(scores, labels, 0.5)
+	report.Accuracy = accuracy(scores, labels)
+
+	return report, nil
+}
+
+// AUC returns the area under the ROC curve, i.e. the probability that a random
+// funded pitch scores higher than a random unfunded one. Ties count as half,
+// and 0.5 is returned when only one outcome is present.
+func AUC(scores []float64, labels []bool) float64 {
+	type pair struct {
+		score float64
+		label bool
+	}
+	pairs := make([]pair, len(scores))
+	for i := range scores {
+		pairs[i] = pair{scores[i], labels[i]}
+	}
+	sort.Slice(pairs, func(i, j int) bool { return pairs[i].score < pairs[j].score })
+
+	// Sum the ranks of the positives, averaging ranks across ties
+	positives, negatives := 0, 0
+	rankSum := 0.0
+	for i := 0; i < len(pairs); {
+		j := i
+		for j < len(pairs) && pairs[j].score == pairs[i].score {
+			j++
+		}
+		rank := float64(i+j+1) / 2
+		for k := i; k < j; k++ {
+			if pairs[k].label {
+				positives++
+				rankSum += rank
+			} else {
+				negatives++
+			}
+		}
+		i = j
+	}
+
+	if positives == 0 || negatives == 0 {
+		return 0.5
+	}
+	return (rankSum - float64(positives*(positives+1))/2) / float64(positives*negatives)
+}
+
+// BrierScore returns the mean squared error of the probabilities
+func BrierScore(scores []float64, labels []bool) float64 {
+	if len(scores) == 0 {
+		return 0
+	}
+	sum := 0.0
+	for i, p := range scores {
+		d := p - indicator(labels[i])
+		sum += d * d
+	}
+	return sum / float64(len(scores))
+}
+
+// LogLoss returns the mean negative log likelihood of the outcomes
+func LogLoss(scores []float64, labels []bool) float64 {
+	if len(scores) == 0 {
+		return 0
+	}
+	const eps = 1e-15
+	sum := 0.0
+	for i, p := range scores {
+		p = clamp(p, eps, 1-eps)
+		if labels[i] {
+			sum -= math.Log(p)
+		} else {
+			sum -= math.Log(1 - p)
+		}
+	}
+	return sum / float64(len(scores))
+}
+
+// Calibration groups predictions into equal width probability bins. Empty bins are left out.
+func Calibration(scores []float64, labels []bool, bins int) []CalibrationBin {
+	result := make([]CalibrationBin, bins)
+	for b := range result {
+		result[b].Lower = float64(b) / float64(bins)
+		result[b].Upper = float64(b+1) / float64(bins)
+	}
+
+	for i, p := range scores {
+		b := int(p * float64(bins))
+		if b >= bins {
+			b = bins - 1
+		}
+		result[b].Count++
+		result[b].MeanPredicted += p
+		result[b].ObservedRate += indicator(labels[i])
+	}
+
+	filled := make([]CalibrationBin, 0, bins)
+	for _, bin := range result {
+		if bin.Count == 0 {
+			continue
+		}
+		bin.MeanPredicted /= float64(bin.Count)
+		bin.ObservedRate /= float64(bin.Count)
+		filled = append(filled, bin)
+	}
+	return filled
+}
+
+// ConfusionAt returns the confusion matrix when predicting a deal at or above threshold
+func ConfusionAt(scores []float64, labels []bool, threshold float64) Confusion {
+	c := Confusion{Threshold: threshold}
+	for i, p := range scores {
+		predicted := p >= threshold
+		switch {
+		case predicted && labels[i]:
+			c.TruePositives++
+		case predicted && !labels[i]:
+			c.FalsePositives++
+		case !predicted && labels[i]:
+			c.FalseNegatives++
+		default:
+			c.TrueNegatives++
+		}
+	}
+	return c
+}
@@ -0,0 +1,119 @@
+package ml
+
+import (
+	"math"
+	"testing"
+)
+
+func TestAUC(t *testing.T) {
+	tests := []struct {
+		name   string
+		scores []float64
+		labels []bool
+		want   float64
+	}{
+		{"perfect", []float64{0.1, 0.2, 0.8, 0.9}, []bool{false, false, true, true}, 1},
+		{"inverted", []float64{0.9, 0.8, 0.2, 0.1}, []bool{false, false, true, true}, 0},
+		{"all tied", []float64{0.5, 0.5, 0.5, 0.5}, []bool{false, true, false, true}, 0.5},
+		{"one outcome", []float64{0.1, 0.9}, []bool{true, true}, 0.5},
+		{"mixed", []float64{0.1, 0.4, 0.35, 0.8}, []bool{false, false, true, true}, 0.75},
+		{"tie across outcomes", []float64{0.2, 0.5, 0.5, 0.9}, []bool{false, false, true, true}, 0.875},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := AUC(tt.scores, tt.labels); math.Abs(got-tt.want) > 1e-12 {
+				t.Errorf("AUC = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestProbabilityScores(t *testing.T) {
+	scores := []float64{0.9, 0.2, 0.6, 0}
+	labels := []bool{true, false, false, false}
+
+	if got, want := BrierScore(scores, labels), (0.01+0.04+0.36+0)/4; math.Abs(got-want) > 1e-12 {
+		t.Errorf("BrierScore = %v, want %v", got, want)
+	}
+	want := -(math.Log(0.9) + math.Log(0.8) + math.Log(0.4) + math.Log(1)) / 4
+	if got := LogLoss(scores, labels); math.Abs(got-want) > 1e-12 {
+		t.Errorf("LogLoss = %v, want %v", got, want)
+	}
+	// A confident miss is clamped instead of costing infinity
+	if got := LogLoss([]float64{0}, []bool{true}); math.IsInf(got, 0) || got < 30 {
+		t.Errorf("LogLoss of a certain miss = %v, want a large finite loss", got)
+	}
+	if BrierScore(nil, nil) != 0 || LogLoss(nil, nil) != 0 {
+		t.Error("scores of no predictions should be 0")
+	}
+}
+
+func TestCalibration(t *testing.T) {
+	scores := []float64{0.05, 0.15, 0.12, 0.95, 1}
+	labels := []bool{false, true, false, true, true}
+
+	bins := Calibration(scores, labels, 10)
+	want := []CalibrationBin{
+		{Lower: 0, Upper: 0.1, Count: 1, MeanPredicted: 0.05, ObservedRate: 0},
+		{Lower: 0.1, Upper: 0.2, Count: 2, MeanPredicted: 0.135, ObservedRate: 0.5},
+		{Lower: 0.9, Upper: 1, Count: 2, MeanPredicted: 0.975, ObservedRate: 1},
+	}
+	if len(bins) != len(want) {
+		t.Fatalf("bins = %+v, want %+v", bins, want)
+	}
+	for i := range bins {
+		got := bins[i]
+		if got.Count != want[i].Count || math.Abs(got.Lower-want[i].Lower) > 1e-12 ||
+			math.Abs(got.MeanPredicted-want[i].MeanPredicted) > 1e-12 || got.ObservedRate != want[i].ObservedRate {
+			t.Errorf("bin %d = %+v, want %+v", i, got, want[i])
+		}
+	}
+}
+
+func TestConfusionAt(t *testing.T) {
+	scores := []float64{0.9, 0.5, 0.4, 0.1, 0.7}
+	labels := []bool{true, false, true, false, true}
+
+	got := ConfusionAt(scores, labels, 0.5)
+	want := Confusion{Threshold: 0.5, TruePositives: 2, FalsePositives: 1, TrueNegatives: 1, FalseNegatives: 1}
+	if got != want {
+		t.Errorf("ConfusionAt = %+v, want %+v", got, want)
+	}
+	if acc := accuracy(scores, labels); acc != 0.6 {
+		t.Errorf("accuracy = %v, want 0.6", acc)
+	}
+}
+
+func TestCrossValidate(t *testing.T) {
+	if _, err := CrossValidate(syntheticDeals(9), 5, DefaultOptions); err != ErrNotEnoughData {
+		t.Errorf("CrossValidate on 9 deals in 5 folds: error = %v, want ErrNotEnoughData", err)
+	}
+	if _, err := CrossValidate(syntheticDeals(40), 1, DefaultOptions); err != ErrNotEnoughData {
+		t.Errorf("CrossValidate with 1 fold: error = %v, want ErrNotEnoughData", err)
+	}
+
+	report, err := CrossValidate(syntheticDeals(60), 3, DefaultOptions)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if report.Deals != 60 || report.Folds != 3 || len(report.FoldAUC) != 3 {
+		t.Errorf("report covers %d deals in %d folds with %d fold AUCs, want 60, 3 and 3",
+			report.Deals, report.Folds, len(report.FoldAUC))
+	}
+	// Revenue predicts four of every five outcomes
+	if report.AUC < 0.7 || report.Accuracy < 0.7 {
+		t.Errorf("held-out AUC %v and accuracy %v, want both at least 0.7", report.AUC, report.Accuracy)
+	}
+	c := report.Confusion
+	if c.TruePositives+c.FalsePositives+c.TrueNegatives+c.FalseNegatives != 60 {
+		t.Errorf("confusion matrix %+v does not cover every deal once", c)
+	}
+	counted := 0
+	for _, bin := range report.Calibration {
+		counted += bin.Count
+	}
+	if counted != 60 {
+		t.Errorf("calibration bins hold %d deals, want 60", counted)
+	}
+}
@@ -0,0 +1,42 @@
+package ml
+
+import (
+	"math"
+	"sort"
+
+	"github.com/your-username/shark-tank-analytics/models"
+)
+
+// Contribution is how much one feature moved a pitch's deal log odds away
+// from those of an average pitch
+type Contribution struct {
+	Feature      string  `json:"feature"`
+	Value        float64 `json:"value"`
+	Contribution float64 `json:"contribution"`
+}
+
+// Contributions returns the per-feature contributions to a pitch's deal log
+// odds, largest effect first. Features are standardized, so an average value
+// contributes nothing and the contributions sum to the logit minus the bias.
+// Only features with no effect at all are left out. A feature the pitch does
+// not report, such as an unknown profit margin, is encoded as 0 just as in
+// training and still contributes by how far 0 lies from the average.
+func (m *Model) Contributions(deal models.Deal) []Contribution {
+	names := m.Encoder.Names()
+	raw := m.Encoder.Raw(deal)
+	x := m.Encoder.Encode(deal)
+
+	contributions := make([]Contribution, 0, len(names))
+	for j, name := range names {
+		c := m.Deal.Weights[j] * x[j]
+		if c == 0 {
+			continue
+		}
+		contributions = append(contributions, Contribution{Feature: name, Value: raw[j], Contribution: c})
+	}
+
+	sort.Slice(contributions, func(i, j int) bool {
+		return math.Abs(contributions[i].Contribution) > math.Abs(contributions[j].Contribution)
+	})
+	return contributions
+}
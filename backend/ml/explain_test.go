@@ -0,0 +1,52 @@
+package ml
+
+import (
+	"math"
+	"testing"
+
+	"github.com/your-username/shark-tank-analytics/models"
+)
+
+func TestContributions(t *testing.T) {
+	revenue := 1e7
+	margin := 15.0
+	training := []models.Deal{
+		{AskAmount: 5e6, AskEquity: 5, RevenueCurrent: &revenue, ProfitMargin: &margin, TeamSize: 20},
+		{AskAmount: 1e7, AskEquity: 10, TeamSize: 4},
+		{AskAmount: 2e6, AskEquity: 2, RevenueCurrent: &revenue, TeamSize: 8, PatentStatus: "granted"},
+	}
+	encoder := NewEncoder(training)
+	names := encoder.Names()
+	m := &Model{Encoder: encoder, Deal: Logistic{Weights: make([]float64, len(names)), Bias: -0.4}}
+	for j := range m.Deal.Weights {
+		m.Deal.Weights[j] = 0.1 * float64(j+1)
+	}
+	// A feature without weight never shows up
+	m.Deal.Weights[6] = 0
+
+	pitch := models.Deal{AskAmount: 5e6, AskEquity: 5, TeamSize: 10}
+	contributions := m.Contributions(pitch)
+
+	total := 0.0
+	byName := make(map[string]Contribution)
+	for i, c := range contributions {
+		total += c.Contribution
+		byName[c.Feature] = c
+		if i > 0 && math.Abs(c.Contribution) > math.Abs(contributions[i-1].Contribution) {
+			t.Errorf("%s is ranked below a smaller contribution", c.Feature)
+		}
+	}
+
+	want := m.Deal.Logit(encoder.Encode(pitch)) - m.Deal.Bias
+	if math.Abs(total-want) > 1e-9 {
+		t.Errorf("contributions sum to %v, want the logit minus the bias %v", total, want)
+	}
+	if _, ok := byName[names[6]]; ok {
+		t.Errorf("%s has no weight but is listed", names[6])
+	}
+	// The pitch reports no profit margin, which still differs from the average
+	c, ok := byName["profit_margin"]
+	if !ok || c.Value != 0 || c.Contribution == 0 {
+		t.Errorf("profit_margin = %+v, want an unreported margin that still contributes", c)
+	}
+}
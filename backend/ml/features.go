@@ -0,0 +1,203 @@
+// Package ml contains the models trained on historical deals and the feature
+// encoding they share. Everything is plain Go so models can be trained offline
+// and loaded by the server without external services.
+package ml
+
+import (
+	"math"
+	"sort"
+	"strings"
+
+	"github.com/your-username/shark-tank-analytics/models"
+)
+
+// Categories seen fewer times than this are folded into the baseline
+const minCategoryCount = 3
+
+var numericFeatures = []string{
+	"log_ask_amount",
+	"ask_equity",
+	"log_ask_valuation",
+	"log_revenue",
+	"has_revenue",
+	"profit_margin",
+	"log_team_size",
+	"has_patent",
+}
+
+// Encoder turns deals into standardized feature vectors. The category
+// vocabularies and scaling are learned from the training deals and saved
+// with the model so predictions are encoded the same way.
+type Encoder struct {
+	Industries []string  `json:"industries"`
+	Locations  []string  `json:"locations"`
+	Means      []float64 `json:"means"`
+	Stds       []float64 `json:"stds"`
+}
+
+// NewEncoder learns the vocabularies and scaling from the given deals
+func NewEncoder(deals []models.Deal) Encoder {
+	e := Encoder{
+		Industries: vocabulary(deals, func(d models.Deal) string { return d.Industry }),
+		Locations:  vocabulary(deals, func(d models.Deal) string { return d.Location }),
+	}
+
+	n := len(e.Names())
+	e.Means = make([]float64, n)
+	e.Stds = make([]float64, n)
+
+	for _, deal := range deals {
+		for i, v := range e.raw(deal) {
+			e.Means[i] += v
+		}
+	}
+	for i := range e.Means {
+		e.Means[i] /= float64(len(deals))
+	}
+	for _, deal := range deals {
+		for i, v := range e.raw(deal) {
+			e.Stds[i] += (v - e.Means[i]) * (v - e.Means[i])
+		}
+	}
+	for i := range e.Stds {
+		e.Stds[i] = math.Sqrt(e.Stds[i] / float64(len(deals)))
+	}
+
+	return e
+}
+
+// Names returns the feature names in vector order
+func (e Encoder) Names() []string {
+	names := append([]string(nil), numericFeatures...)
+	for _, industry := range e.Industries {
+		names = append(names, "industry="+industry)
+	}
+	for _, location := range e.Locations {
+		names = append(names, "location="+location)
+	}
+	return names
+}
+
+// Encode returns the standardized feature vector of a deal
+func (e Encoder) Encode(deal models.Deal) []float64 {
+	x := e.raw(deal)
+	for i := range x {
+		if e.Stds[i] > 0 {
+			x[i] = (x[i] - e.Means[i]) / e.Stds[i]
+		} else {
+			x[i] = 0
+		}
+	}
+	return x
+}
+
+// Raw returns the unscaled feature vector of a deal, useful for explaining predictions
+func (e Encoder) Raw(deal models.Deal) []float64 {
+	return e.raw(deal)
+}
+
+func (e Encoder) raw(deal models.Deal) []float64 {
+	askValuation := AskValuation(deal)
+
+	x := []float64{
+		math.Log1p(math.Max(deal.AskAmount, 0)),
+		deal.AskEquity,
+		math.Log1p(math.Max(askValuation, 0)),
+		math.Log1p(math.Max(Revenue(deal), 0)),
+		indicator(Revenue(deal) > 0),
+		ProfitMargin(deal),
+		math.Log1p(math.Max(float64(deal.TeamSize), 0)),
+		indicator(HasPatent(deal)),
+	}
+
+	industry := normalizeCategory(deal.Industry)
+	for _, v := range e.Industries {
+		x = append(x, indicator(v == industry))
+	}
+	location := normalizeCategory(deal.Location)
+	for _, v := range e.Locations {
+		x = append(x, indicator(v == location))
+	}
+
+	return x
+}
+
+// AskValuation returns the valuation the founders asked for, derived from the
+// ask terms when no valuation was recorded
+func AskValuation(deal models.Deal) float64 {
+	if deal.Valuation > 0 {
+		return deal.Valuation
+	}
+	if deal.AskEquity > 0 {
+		return deal.AskAmount * 100 / deal.AskEquity
+	}
+	return 0
+}
+
+// Revenue returns the current revenue of a startup, 0 when it was not reported
+func Revenue(deal models.Deal) float64 {
+	if deal.RevenueCurrent == nil {
+		return 0
+	}
+	return *deal.RevenueCurrent
+}
+
+// ProfitMargin returns the profit margin of a startup in percent, 0 when it
+// was not reported
+func ProfitMargin(deal models.Deal) float64 {
+	if deal.ProfitMargin == nil {
+		return 0
+	}
+	return *deal.ProfitMargin
+}
+
+// DealValuation returns the valuation implied by the final deal terms
+func DealValuation(deal models.Deal) float64 {
+	if deal.DealEquity > 0 {
+		return deal.DealAmount * 100 / deal.DealEquity
+	}
+	return 0
+}
+
+// Funded reports whether a pitch ended with a deal
+func Funded(deal models.Deal) bool {
+	return strings.EqualFold(deal.SuccessStatus, "funded") || deal.DealAmount > 0
+}
+
+// HasPatent reports whether the startup holds or has applied for a patent
+func HasPatent(deal models.Deal) bool {
+	switch normalizeCategory(deal.PatentStatus) {
+	case "", "none", "no", "n/a", "na":
+		return false
+	}
+	return true
+}
+
+func vocabulary(deals []models.Deal, field func(models.Deal) string) []string {
+	counts := make(map[string]int)
+	for _, deal := range deals {
+		if v := normalizeCategory(field(deal)); v != "" {
+			counts[v]++
+		}
+	}
+
+	values := make([]string, 0)
+	for v, count := range counts {
+		if count >= minCategoryCount {
+			values = append(values, v)
+		}
+	}
+	sort.Strings(values)
+	return values
+}
+
+func normalizeCategory(s string) string {
+	return strings.ToLower(strings.TrimSpace(s))
+}
+
+func indicator(b bool) float64 {
+	if b {
+		return 1
+	}
+	return 0
+}
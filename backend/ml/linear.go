@@ -0,0 +1,165 @@
+package ml
+
+import (
+	"math"
+	"sort"
+)
+
+// Linear is a ridge regression. Lower and Upper are added to a point
+// prediction to give its 90% prediction interval; they are conformal bounds
+// taken from out-of-fold residuals, so they hold for pitches the model has
+// not seen rather than only for its training data.
+type Linear struct {
+	Weights []float64 `json:"weights"`
+	Bias    float64   `json:"bias"`
+	Lower   float64   `json:"lower"`
+	Upper   float64   `json:"upper"`
+}
+
+// Number of folds whose held-out residuals calibrate the prediction interval
+const conformalFolds = 5
+
+// Miscoverage of the prediction interval, split evenly between both tails
+const conformalAlpha = 0.1
+
+// FitLinear trains a ridge regression on all rows and calibrates its
+// prediction interval on the residuals of cross-validated refits. The bias is
+// not regularized.
+func FitLinear(X [][]float64, y []float64, l2 float64) Linear {
+	if len(X) == 0 {
+		return Linear{}
+	}
+
+	m := fitRidge(X, y, l2)
+	m.Lower, m.Upper = conformalBounds(heldOutResiduals(X, y, l2), conformalAlpha)
+	return m
+}
+
+// fitRidge solves the normal equations of a ridge regression
+func fitRidge(X [][]float64, y []float64, l2 float64) Linear {
+	d := len(X[0])
+
+	// Augment with a constant column for the bias
+	size := d + 1
+	a := make([][]float64, size)
+	for i := range a {
+		a[i] = make([]float64, size+1)
+	}
+	for i, x := range X {
+		row := append(append([]float64(nil), x...), 1)
+		for j := 0; j < size; j++ {
+			for k := 0; k < size; k++ {
+				a[j][k] += row[j] * row[k]
+			}
+			a[j][size] += row[j] * y[i]
+		}
+	}
+	for j := 0; j < d; j++ {
+		a[j][j] += l2 * float64(len(X))
+	}
+
+	solution := solve(a)
+	return Linear{Weights: solution[:d], Bias: solution[d]}
+}
+
+// heldOutResiduals returns the residual of every row predicted by a model fit
+// without its fold. Too few rows to split fall back to in-sample residuals.
+func heldOutResiduals(X [][]float64, y []float64, l2 float64) []float64 {
+	residuals := make([]float64, len(X))
+	if len(X) < 2*conformalFolds {
+		m := fitRidge(X, y, l2)
+		for i, x := range X {
+			residuals[i] = y[i] - m.Predict(x)
+		}
+		return residuals
+	}
+
+	for fold := 0; fold < conformalFolds; fold++ {
+		var trainX [][]float64
+		var trainY []float64
+		for i := range X {
+			if i%conformalFolds != fold {
+				trainX = append(trainX, X[i])
+				trainY = append(trainY, y[i])
+			}
+		}
+		m := fitRidge(trainX, trainY, l2)
+		for i := fold; i < len(X); i += conformalFolds {
+			residuals[i] = y[i] - m.Predict(X[i])
+		}
+	}
+	return residuals
+}
+
+// conformalBounds returns the split conformal bounds for residuals: the
+// floor(alpha/2*(n+1))-th and ceil((1-alpha/2)*(n+1))-th smallest residuals,
+// clamped to the extremes when n is too small to reach those ranks
+func conformalBounds(residuals []float64, alpha float64) (lower, upper float64) {
+	n := len(residuals)
+	if n == 0 {
+		return 0, 0
+	}
+	sorted := append([]float64(nil), residuals...)
+	sort.Float64s(sorted)
+
+	low := int(math.Floor(alpha / 2 * float64(n+1)))
+	high := int(math.Ceil((1 - alpha/2) * float64(n+1)))
+	if low < 1 {
+		low = 1
+	}
+	if high > n {
+		high = n
+	}
+	return sorted[low-1], sorted[high-1]
+}
+
+// Predict returns the point prediction for a feature vector
+func (m Linear) Predict(x []float64) float64 {
+	v := m.Bias
+	for j, xv := range x {
+		v += m.Weights[j] * xv
+	}
+	return v
+}
+
+// Interval returns the point prediction with its 90% prediction interval
+func (m Linear) Interval(x []float64) (low, point, high float64) {
+	point = m.Predict(x)
+	return point + m.Lower, point, point + m.Upper
+}
+
+// solve runs Gaussian elimination with partial pivoting on an augmented matrix
+func solve(a [][]float64) []float64 {
+	n := len(a)
+	for col := 0; col < n; col++ {
+		pivot := col
+		for row := col + 1; row < n; row++ {
+			if math.Abs(a[row][col]) > math.Abs(a[pivot][col]) {
+				pivot = row
+			}
+		}
+		a[col], a[pivot] = a[pivot], a[col]
+		if math.Abs(a[col][col]) < 1e-12 {
+			continue
+		}
+		for row := col + 1; row < n; row++ {
+			factor := a[row][col] / a[col][col]
+			for k := col; k <= n; k++ {
+				a[row][k] -= factor * a[col][k]
+			}
+		}
+	}
+
+	x := make([]float64, n)
+	for row := n - 1; row >= 0; row-- {
+		if math.Abs(a[row][row]) < 1e-12 {
+			continue
+		}
+		sum := a[row][n]
+		for k := row + 1; k < n; k++ {
+			sum -= a[row][k] * x[k]
+		}
+		x[row] = sum / a[row][row]
+	}
+	return x
+}
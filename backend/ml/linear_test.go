@@ -0,0 +1,89 @@
+package ml
+
+import (
+	"math"
+	"testing"
+)
+
+func TestFitLinear(t *testing.T) {
+	// y = 1 + 2a - b exactly, so there is nothing left for the interval
+	var X [][]float64
+	var y []float64
+	for i := 0; i < 20; i++ {
+		a, b := float64(i%7), float64(i%3)
+		X = append(X, []float64{a, b})
+		y = append(y, 1+2*a-b)
+	}
+
+	m := FitLinear(X, y, 0)
+	if math.Abs(m.Bias-1) > 1e-9 || math.Abs(m.Weights[0]-2) > 1e-9 || math.Abs(m.Weights[1]+1) > 1e-9 {
+		t.Errorf("fit = %v + %v·x, want 1 + [2 -1]·x", m.Bias, m.Weights)
+	}
+	low, point, high := m.Interval([]float64{10, 1})
+	if math.Abs(point-20) > 1e-9 || math.Abs(high-low) > 1e-9 {
+		t.Errorf("interval = [%v, %v, %v], want a point at 20 with no width", low, point, high)
+	}
+
+	// Ridge shrinks the weights but leaves the bias alone
+	ridge := FitLinear(X, y, 1)
+	if math.Abs(ridge.Weights[0]) >= 2 || ridge.Lower >= 0 || ridge.Upper <= 0 {
+		t.Errorf("ridge fit = %+v, want shrunk weights and residuals on both sides", ridge)
+	}
+
+	if empty := FitLinear(nil, nil, 0.1); empty.Weights != nil {
+		t.Errorf("FitLinear without rows = %+v, want the zero model", empty)
+	}
+}
+
+func TestFitLinearCoverage(t *testing.T) {
+	// Noise from a fixed sequence, so coverage on fresh rows is repeatable
+	noise := func(i int) float64 { return math.Sin(float64(i)*12.9898) * 3 }
+
+	var X [][]float64
+	var y []float64
+	for i := 0; i < 200; i++ {
+		x := float64(i % 20)
+		X = append(X, []float64{x})
+		y = append(y, 5+0.5*x+noise(i))
+	}
+	m := FitLinear(X, y, 0.01)
+
+	covered := 0
+	for i := 200; i < 1200; i++ {
+		x := float64(i % 20)
+		low, _, high := m.Interval([]float64{x})
+		if v := 5 + 0.5*x + noise(i); v >= low && v <= high {
+			covered++
+		}
+	}
+	if rate := float64(covered) / 1000; rate < 0.85 || rate > 0.95 {
+		t.Errorf("interval covers %.1f%% of new rows, want about 90%%", 100*rate)
+	}
+}
+
+func TestConformalBounds(t *testing.T) {
+	residuals := make([]float64, 19)
+	for i := range residuals {
+		residuals[i] = float64(9 - i)
+	}
+
+	tests := []struct {
+		name      string
+		residuals []float64
+		wantLow   float64
+		wantHigh  float64
+	}{
+		{"none", nil, 0, 0},
+		{"too few for the ranks", []float64{-2, 3, 1}, -2, 3},
+		{"ranks 1 and 19 of 19", residuals, -9, 9},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			low, high := conformalBounds(tt.residuals, conformalAlpha)
+			if low != tt.wantLow || high != tt.wantHigh {
+				t.Errorf("conformalBounds = (%v, %v), want (%v, %v)", low, high, tt.wantLow, tt.wantHigh)
+			}
+		})
+	}
+}
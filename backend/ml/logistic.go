@@ -0,0 +1,73 @@
+package ml
+
+import (
+	"math"
+)
+
+// Logistic is an L2 regularized logistic regression
+type Logistic struct {
+	Weights []float64 `json:"weights"`
+	Bias    float64   `json:"bias"`
+}
+
+// FitLogistic trains a logistic regression with full batch gradient descent.
+// Labels are 0 or 1; the bias is not regularized.
+func FitLogistic(X [][]float64, y []float64, l2 float64, iterations int) Logistic {
+	d := 0
+	if len(X) > 0 {
+		d = len(X[0])
+	}
+	m := Logistic{Weights: make([]float64, d)}
+
+	// Start from the base rate so early steps only have to learn the features
+	mean := 0.0
+	for _, v := range y {
+		mean += v
+	}
+	mean = (mean + 0.5) / (float64(len(y)) + 1)
+	m.Bias = math.Log(mean / (1 - mean))
+
+	const learningRate = 0.5
+	n := float64(len(X))
+	grad := make([]float64, d)
+
+	for iter := 0; iter < iterations; iter++ {
+		for j := range grad {
+			grad[j] = 0
+		}
+		gradBias := 0.0
+
+		for i, x := range X {
+			err := m.Probability(x) - y[i]
+			for j, v := range x {
+				grad[j] += err * v
+			}
+			gradBias += err
+		}
+
+		for j := range m.Weights {
+			m.Weights[j] -= learningRate * (grad[j]/n + l2*m.Weights[j])
+		}
+		m.Bias -= learningRate * gradBias / n
+	}
+
+	return m
+}
+
+// Logit returns the log odds for a feature vector
+func (m Logistic) Logit(x []float64) float64 {
+	z := m.Bias
+	for j, v := range x {
+		z += m.Weights[j] * v
+	}
+	return z
+}
+
+// Probability returns the predicted probability for a feature vector
+func (m Logistic) Probability(x []float64) float64 {
+	return sigmoid(m.Logit(x))
+}
+
+func sigmoid(z float64) float64 {
+	return 1 / (1 + math.Exp(-z))
+}
@@ -0,0 +1,35 @@
+package ml
+
+import (
+	"math"
+	"testing"
+)
+
+func TestFitLogistic(t *testing.T) {
+	// Deals are likelier the higher x, with overlap so the weights stay finite
+	X := [][]float64{{-2}, {-1.5}, {-1}, {-0.5}, {0}, {0.5}, {1}, {1.5}, {2}, {0.25}}
+	y := []float64{0, 0, 0, 1, 0, 1, 1, 1, 1, 0}
+
+	m := FitLogistic(X, y, 0.01, 400)
+	if m.Weights[0] <= 0 {
+		t.Errorf("weight = %v, want a positive effect", m.Weights[0])
+	}
+	if low, high := m.Probability([]float64{-2}), m.Probability([]float64{2}); low >= 0.5 || high <= 0.5 {
+		t.Errorf("probabilities = %v at -2 and %v at 2, want below and above one half", low, high)
+	}
+	if z := m.Logit([]float64{1}); math.Abs(m.Probability([]float64{1})-1/(1+math.Exp(-z))) > 1e-12 {
+		t.Error("probability is not the sigmoid of the logit")
+	}
+
+	// Without features the bias settles on the smoothed base rate
+	base := FitLogistic([][]float64{{}, {}, {}, {}}, []float64{1, 0, 0, 0}, 0.01, 400)
+	if p := base.Probability(nil); math.Abs(p-0.25) > 0.01 {
+		t.Errorf("base rate = %v, want 0.25", p)
+	}
+
+	// Stronger regularization pulls the weights towards 0
+	strong := FitLogistic(X, y, 1, 400)
+	if math.Abs(strong.Weights[0]) >= math.Abs(m.Weights[0]) {
+		t.Errorf("weight with l2 = 1 is %v, want it smaller than %v", strong.Weights[0], m.Weights[0])
+	}
+}
@@ -0,0 +1,262 @@
+package ml
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"math/rand"
+	"os"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/your-username/shark-tank-analytics/models"
+	"github.com/your-username/shark-tank-analytics/stats"
+)
+
+// versionPattern is what a model version may consist of, as it becomes part
+// of the artifact's file name
+var versionPattern = regexp.MustCompile(`^[A-Za-z0-9._-]+$`)
+
+// ValidVersion reports whether a model version is safe to use in an artifact
+// file name: letters, digits, dots, dashes and underscores, and no "..".
+func ValidVersion(version string) bool {
+	return versionPattern.MatchString(version) && !strings.Contains(version, "..")
+}
+
+// ErrNotEnoughData is returned when there are too few deals, or too few
+// funded and unfunded pitches, to train a model
+var ErrNotEnoughData = errors.New("not enough deals to train a model")
+
+// Options controls model training
+type Options struct {
+	L2               float64 `json:"l2"`
+	Iterations       int     `json:"iterations"`
+	BootstrapSamples int     `json:"bootstrap_samples"`
+	Seed             int64   `json:"seed"`
+}
+
+// DefaultOptions are the training options used by the train command
+var DefaultOptions = Options{
+	L2:               0.01,
+	Iterations:       400,
+	BootstrapSamples: 30,
+	Seed:             1,
+}
+
+// Model predicts the outcome of a pitch: whether it gets a deal and, if it
+// does, the equity, amount and valuation of the counter-offer
+type Model struct {
+	Version      string    `json:"version"`
+	TrainedAt    time.Time `json:"trained_at"`
+	TrainingSize int       `json:"training_size"`
+	// TrainingDeals are the sorted IDs of the deals the model was fitted on
+	TrainingDeals []uint     `json:"training_deals"`
+	Options       Options    `json:"options"`
+	Encoder       Encoder    `json:"encoder"`
+	Deal          Logistic   `json:"deal"`
+	Bootstrap     []Logistic `json:"bootstrap"`
+	Equity        Linear     `json:"equity"`
+	Amount        Linear     `json:"amount"`
+	Valuation     Linear     `json:"valuation"`
+}
+
+// Interval is a 90% range around a prediction. For the regressions it is a
+// conformal prediction interval calibrated on held-out residuals; for the deal
+// probability it is only the band of bootstrap refits (see ProbabilityBand).
+type Interval struct {
+	Low  float64 `json:"low"`
+	High float64 `json:"high"`
+}
+
+// Prediction is the predicted outcome of a pitch
+type Prediction struct {
+	DealProbability float64 `json:"deal_probability"`
+	// ProbabilityBand is the 5th to 95th percentile of the deal probability
+	// across bootstrap refits. It shows how stable the estimate is and is not
+	// a calibrated confidence interval.
+	ProbabilityBand   Interval `json:"probability_band"`
+	ExpectedEquity    float64  `json:"expected_equity"`
+	EquityInterval    Interval `json:"equity_interval"`
+	ExpectedAmount    float64  `json:"expected_amount"`
+	AmountInterval    Interval `json:"amount_interval"`
+	AskValuation      float64  `json:"ask_valuation"`
+	CounterValuation  float64  `json:"counter_valuation"`
+	ValuationInterval Interval `json:"valuation_interval"`
+	ValuationHaircut  float64  `json:"valuation_haircut"`
+	ModelVersion      string   `json:"model_version"`
+}
+
+// Train fits a model on historical deals.
+//
+// The deal probability comes from a logistic regression over all pitches. Its
+// interval is the spread of models refit on bootstrap resamples, so it shows how
+// uncertain the estimated probability is and is not a calibrated interval.
+// Equity, amount and the counter-offer valuation are ridge regressions over
+// funded pitches only, with prediction intervals calibrated on held-out
+// residuals (see FitLinear). The amount is fitted in log space and the
+// valuation as the log ratio of the valuation implied by the deal to the
+// valuation implied by the ask.
+func Train(deals []models.Deal, opts Options) (*Model, error) {
+	funded := make([]models.Deal, 0)
+	for _, deal := range deals {
+		if Funded(deal) {
+			funded = append(funded, deal)
+		}
+	}
+	if len(deals) < 10 || len(funded) < 5 || len(deals)-len(funded) < 5 {
+		return nil, ErrNotEnoughData
+	}
+
+	m := &Model{
+		TrainedAt:     time.Now().UTC(),
+		TrainingSize:  len(deals),
+		TrainingDeals: dealIDs(deals),
+		Options:       opts,
+		Encoder:       NewEncoder(deals),
+	}
+
+	X := make([][]float64, len(deals))
+	y := make([]float64, len(deals))
+	for i, deal := range deals {
+		X[i] = m.Encoder.Encode(deal)
+		y[i] = indicator(Funded(deal))
+	}
+	m.Deal = FitLogistic(X, y, opts.L2, opts.Iterations)
+
+	rng := rand.New(rand.NewSource(opts.Seed))
+	for b := 0; b < opts.BootstrapSamples; b++ {
+		bx := make([][]float64, len(X))
+		by := make([]float64, len(y))
+		for i := range bx {
+			k := rng.Intn(len(X))
+			bx[i], by[i] = X[k], y[k]
+		}
+		m.Bootstrap = append(m.Bootstrap, FitLogistic(bx, by, opts.L2, opts.Iterations))
+	}
+
+	fx := make([][]float64, len(funded))
+	equity := make([]float64, len(funded))
+	amount := make([]float64, len(funded))
+	for i, deal := range funded {
+		fx[i] = m.Encoder.Encode(deal)
+		equity[i] = deal.DealEquity
+		amount[i] = math.Log1p(deal.DealAmount)
+	}
+	m.Equity = FitLinear(fx, equity, opts.L2)
+	m.Amount = FitLinear(fx, amount, opts.L2)
+
+	// Deals without equity, e.g. pure debt, say nothing about valuation
+	var vx [][]float64
+	var haircut []float64
+	for i, deal := range funded {
+		ask, final := AskValuation(deal), DealValuation(deal)
+		if ask > 0 && final > 0 {
+			vx = append(vx, fx[i])
+			haircut = append(haircut, math.Log(final/ask))
+		}
+	}
+	m.Valuation = FitLinear(vx, haircut, opts.L2)
+
+	return m, nil
+}
+
+// Predict scores a pitch. Only the ask side of the deal is used.
+func (m *Model) Predict(deal models.Deal) Prediction {
+	x := m.Encoder.Encode(deal)
+
+	p := Prediction{
+		DealProbability: m.Deal.Probability(x),
+		ModelVersion:    m.Version,
+	}
+
+	if len(m.Bootstrap) > 0 {
+		samples := make([]float64, len(m.Bootstrap))
+		for i, b := range m.Bootstrap {
+			samples[i] = b.Probability(x)
+		}
+		p.ProbabilityBand = Interval{Low: stats.Quantile(samples, 0.05), High: stats.Quantile(samples, 0.95)}
+	} else {
+		p.ProbabilityBand = Interval{Low: p.DealProbability, High: p.DealProbability}
+	}
+
+	low, point, high := m.Equity.Interval(x)
+	p.ExpectedEquity = clamp(point, 0, 100)
+	p.EquityInterval = Interval{Low: clamp(low, 0, 100), High: clamp(high, 0, 100)}
+
+	low, point, high = m.Amount.Interval(x)
+	p.ExpectedAmount = math.Max(math.Expm1(point), 0)
+	p.AmountInterval = Interval{Low: math.Max(math.Expm1(low), 0), High: math.Max(math.Expm1(high), 0)}
+
+	// Models trained before the valuation regression existed have no weights for it
+	p.AskValuation = AskValuation(deal)
+	if p.AskValuation > 0 && len(m.Valuation.Weights) == len(x) {
+		low, point, high = m.Valuation.Interval(x)
+		p.CounterValuation = p.AskValuation * math.Exp(point)
+		p.ValuationInterval = Interval{Low: p.AskValuation * math.Exp(low), High: p.AskValuation * math.Exp(high)}
+		p.ValuationHaircut = 1 - math.Exp(point)
+	}
+
+	return p
+}
+
+// Seen reports whether a deal was in the model's training set, so that its
+// prediction says nothing about how the model does on new pitches. Artifacts
+// saved before the training set was recorded count every deal as seen.
+func (m *Model) Seen(dealID uint) bool {
+	if m.TrainingDeals == nil {
+		return true
+	}
+	i := sort.Search(len(m.TrainingDeals), func(i int) bool { return m.TrainingDeals[i] >= dealID })
+	return i < len(m.TrainingDeals) && m.TrainingDeals[i] == dealID
+}
+
+// TrainingOptions returns the options the model was trained with, so it can
+// be retrained the same way, e.g. by a backtest filed under its version
+func (m *Model) TrainingOptions() (Options, error) {
+	if m.Options.Iterations == 0 {
+		return Options{}, fmt.Errorf("model %s was trained before training options were recorded, retrain it", m.Version)
+	}
+	return m.Options, nil
+}
+
+// Save writes the model as JSON
+func (m *Model) Save(path string) error {
+	data, err := json.MarshalIndent(m, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0644)
+}
+
+// Load reads a model written by Save
+func Load(path string) (*Model, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var m Model
+	if err := json.Unmarshal(data, &m); err != nil {
+		return nil, err
+	}
+	if len(m.Deal.Weights) != len(m.Encoder.Names()) {
+		return nil, errors.New("model weights do not match its features")
+	}
+	return &m, nil
+}
+
+func clamp(v, low, high float64) float64 {
+	return math.Min(math.Max(v, low), high)
+}
+
+func dealIDs(deals []models.Deal) []uint {
+	ids := make([]uint, len(deals))
+	for i, deal := range deals {
+		ids[i] = deal.ID
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+	return ids
+}
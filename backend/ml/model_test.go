@@ -0,0 +1,173 @@
+package ml
+
+import (
+	"fmt"
+	"math"
+	"path/filepath"
+	"testing"
+
+	"github.com/your-username/shark-tank-analytics/models"
+)
+
+func TestModelSeen(t *testing.T) {
+	m := &Model{TrainingDeals: dealIDs(nil)}
+	if m.Seen(1) {
+		t.Error("a model trained on no deals has seen deal 1")
+	}
+
+	m.TrainingDeals = []uint{2, 5, 9}
+	for id, want := range map[uint]bool{1: false, 2: true, 5: true, 6: false, 9: true, 10: false} {
+		if got := m.Seen(id); got != want {
+			t.Errorf("Seen(%d) = %v, want %v", id, got, want)
+		}
+	}
+
+	// Artifacts from before the training set was recorded are never out-of-sample
+	if !(&Model{}).Seen(1) {
+		t.Error("a model without a recorded training set has not seen deal 1")
+	}
+}
+
+func TestValidVersion(t *testing.T) {
+	tests := map[string]bool{
+		"20250101-120000": true,
+		"v1.2_rc-3":       true,
+		"":                false,
+		"..":              false,
+		"v1..2":           false,
+		"../secrets":      false,
+		"a/b":             false,
+		`a\b`:             false,
+		"v 1":             false,
+	}
+	for version, want := range tests {
+		if got := ValidVersion(version); got != want {
+			t.Errorf("ValidVersion(%q) = %v, want %v", version, got, want)
+		}
+	}
+}
+
+// syntheticDeals returns n pitches spread over three seasons where having
+// revenue predicts a deal, with every fifth outcome flipped as noise. Deals are
+// closed at the ask amount for half again the asked equity.
+func syntheticDeals(n int) []models.Deal {
+	industries := []string{"Food", "Beauty", "Technology", "Apparel"}
+	deals := make([]models.Deal, n)
+	for i := range deals {
+		k := i + 1
+		deal := models.Deal{
+			ID:          uint(k),
+			StartupName: fmt.Sprintf("Startup %d", k),
+			Season:      1 + 3*i/n,
+			Industry:    industries[k%len(industries)],
+			AskAmount:   1e6 * float64(1+k%5),
+			AskEquity:   float64(2 + k%8),
+			TeamSize:    3 + k%10,
+		}
+		hasRevenue := k%2 == 0
+		if hasRevenue {
+			revenue := 1e7 * float64(1+k%3)
+			deal.RevenueCurrent = &revenue
+		}
+		if hasRevenue != (k%5 == 0) {
+			deal.SuccessStatus = "funded"
+			deal.DealAmount = deal.AskAmount
+			deal.DealEquity = deal.AskEquity * 1.5
+		}
+		deals[i] = deal
+	}
+	return deals
+}
+
+func TestTrainNotEnoughData(t *testing.T) {
+	funded := syntheticDeals(40)
+	for i := range funded {
+		funded[i].DealAmount = 1e6
+	}
+
+	tests := map[string][]models.Deal{
+		"too few deals":     syntheticDeals(9),
+		"no unfunded deals": funded,
+		"no deals":          nil,
+	}
+	for name, deals := range tests {
+		if _, err := Train(deals, DefaultOptions); err != ErrNotEnoughData {
+			t.Errorf("%s: Train error = %v, want ErrNotEnoughData", name, err)
+		}
+	}
+}
+
+func TestTrainPredict(t *testing.T) {
+	deals := syntheticDeals(40)
+	m, err := Train(deals, DefaultOptions)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if m.TrainingSize != 40 || len(m.TrainingDeals) != 40 || !m.Seen(40) || m.Seen(41) {
+		t.Errorf("training set = %d deals %v, want deals 1 to 40", m.TrainingSize, m.TrainingDeals)
+	}
+	if len(m.Bootstrap) != DefaultOptions.BootstrapSamples {
+		t.Errorf("got %d bootstrap models, want %d", len(m.Bootstrap), DefaultOptions.BootstrapSamples)
+	}
+
+	revenue := 2e7
+	withRevenue := models.Deal{AskAmount: 3e6, AskEquity: 5, TeamSize: 8, RevenueCurrent: &revenue}
+	withoutRevenue := models.Deal{AskAmount: 3e6, AskEquity: 5, TeamSize: 8}
+
+	p := m.Predict(withRevenue)
+	if q := m.Predict(withoutRevenue); p.DealProbability <= q.DealProbability {
+		t.Errorf("deal probability with revenue %v is not above %v without", p.DealProbability, q.DealProbability)
+	}
+	if p.ProbabilityBand.Low > p.ProbabilityBand.High || p.ProbabilityBand.Low < 0 || p.ProbabilityBand.High > 1 {
+		t.Errorf("probability band = %+v, want an ordered range within [0, 1]", p.ProbabilityBand)
+	}
+	if p.EquityInterval.Low > p.ExpectedEquity || p.EquityInterval.High < p.ExpectedEquity {
+		t.Errorf("equity interval %+v does not contain %v", p.EquityInterval, p.ExpectedEquity)
+	}
+	if p.AmountInterval.Low > p.ExpectedAmount || p.AmountInterval.High < p.ExpectedAmount {
+		t.Errorf("amount interval %+v does not contain %v", p.AmountInterval, p.ExpectedAmount)
+	}
+
+	// Every deal closed at two thirds of the asked valuation
+	if p.AskValuation != 6e7 || math.Abs(p.ValuationHaircut-1.0/3) > 0.01 {
+		t.Errorf("ask valuation %v with haircut %v, want 6e7 and 1/3", p.AskValuation, p.ValuationHaircut)
+	}
+	if math.Abs(p.CounterValuation-4e7) > 1e6 {
+		t.Errorf("counter valuation = %v, want about 4e7", p.CounterValuation)
+	}
+}
+
+func TestSaveLoad(t *testing.T) {
+	m, err := Train(syntheticDeals(30), DefaultOptions)
+	if err != nil {
+		t.Fatal(err)
+	}
+	m.Version = "test"
+
+	path := filepath.Join(t.TempDir(), "model.json")
+	if err := m.Save(path); err != nil {
+		t.Fatal(err)
+	}
+	loaded, err := Load(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	pitch := syntheticDeals(30)[3]
+	if got, want := loaded.Predict(pitch), m.Predict(pitch); got != want {
+		t.Errorf("loaded model predicts %+v, want %+v", got, want)
+	}
+	if opts, err := loaded.TrainingOptions(); err != nil || opts != DefaultOptions {
+		t.Errorf("training options = %+v, %v, want %+v", opts, err, DefaultOptions)
+	}
+
+	// Weights that do not match the features cannot be used
+	m.Deal.Weights = m.Deal.Weights[1:]
+	if err := m.Save(path); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Load(path); err == nil {
+		t.Error("Load accepted a model whose weights do not match its features")
+	}
+}
@@ -0,0 +1,152 @@
+package ml
+
+import (
+	"math"
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// Words too common in pitches to say anything about the industry
+var stopWords = map[string]bool{
+	"a": true, "an": true, "and": true, "are": true, "as": true, "at": true,
+	"be": true, "by": true, "for": true, "from": true, "has": true, "have": true,
+	"in": true, "is": true, "it": true, "its": true, "of": true, "on": true,
+	"or": true, "our": true, "that": true, "the": true, "their": true, "this": true,
+	"to": true, "we": true, "with": true, "you": true, "your": true,
+	"pvt": true, "ltd": true, "private": true, "limited": true, "company": true,
+	"startup": true, "brand": true, "india": true, "indian": true,
+}
+
+// Tokenize lowercases text and splits it into words, dropping stop words,
+// numbers and single letters
+func Tokenize(text string) []string {
+	fields := strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+
+	tokens := make([]string, 0, len(fields))
+	for _, field := range fields {
+		if len(field) < 2 || stopWords[field] || strings.IndexFunc(field, unicode.IsLetter) < 0 {
+			continue
+		}
+		tokens = append(tokens, field)
+	}
+	return tokens
+}
+
+// NaiveBayes is a multinomial naive Bayes text classifier with Laplace smoothing.
+// It keeps raw counts rather than probabilities so that a training document can
+// be scored as if it had been left out, which is how mislabeled rows are found.
+type NaiveBayes struct {
+	Docs       int                       `json:"docs"`
+	ClassDocs  map[string]int            `json:"class_docs"`
+	ClassWords map[string]int            `json:"class_words"`
+	WordCounts map[string]map[string]int `json:"word_counts"`
+	Vocabulary map[string]int            `json:"vocabulary"`
+}
+
+// ClassScore is the posterior probability of one class
+type ClassScore struct {
+	Class       string  `json:"class"`
+	Probability float64 `json:"probability"`
+}
+
+// NewNaiveBayes returns an empty classifier
+func NewNaiveBayes() *NaiveBayes {
+	return &NaiveBayes{
+		ClassDocs:  make(map[string]int),
+		ClassWords: make(map[string]int),
+		WordCounts: make(map[string]map[string]int),
+		Vocabulary: make(map[string]int),
+	}
+}
+
+// Add trains the classifier on one document
+func (nb *NaiveBayes) Add(tokens []string, class string) {
+	nb.Docs++
+	nb.ClassDocs[class]++
+	if nb.WordCounts[class] == nil {
+		nb.WordCounts[class] = make(map[string]int)
+	}
+	for _, token := range tokens {
+		nb.WordCounts[class][token]++
+		nb.ClassWords[class]++
+		nb.Vocabulary[token]++
+	}
+}
+
+// Classify returns the posterior probability of every class, most likely first
+func (nb *NaiveBayes) Classify(tokens []string) []ClassScore {
+	return nb.classify(tokens, "")
+}
+
+// ClassifyHeldOut scores a document the classifier was trained on with its own
+// counts removed from the given class, as if it had never been seen
+func (nb *NaiveBayes) ClassifyHeldOut(tokens []string, class string) []ClassScore {
+	return nb.classify(tokens, class)
+}
+
+func (nb *NaiveBayes) classify(tokens []string, exclude string) []ClassScore {
+	own := make(map[string]int)
+	if exclude != "" {
+		for _, token := range tokens {
+			own[token]++
+		}
+	}
+
+	docs := nb.Docs
+	if exclude != "" {
+		docs--
+	}
+	if docs <= 0 {
+		return []ClassScore{}
+	}
+	vocabulary := float64(len(nb.Vocabulary))
+
+	classes := make([]string, 0, len(nb.ClassDocs))
+	logs := make([]float64, 0, len(nb.ClassDocs))
+	for class, classDocs := range nb.ClassDocs {
+		classWords := nb.ClassWords[class]
+		if class == exclude {
+			classDocs--
+			classWords -= len(tokens)
+		}
+		if classDocs <= 0 {
+			continue
+		}
+
+		score := math.Log(float64(classDocs) / float64(docs))
+		for _, token := range tokens {
+			count := nb.WordCounts[class][token]
+			if class == exclude {
+				count -= own[token]
+			}
+			score += math.Log((float64(count) + 1) / (float64(classWords) + vocabulary))
+		}
+		classes = append(classes, class)
+		logs = append(logs, score)
+	}
+
+	// Normalize in log space to avoid underflow on long pitches
+	maxLog := math.Inf(-1)
+	for _, l := range logs {
+		maxLog = math.Max(maxLog, l)
+	}
+	total := 0.0
+	for _, l := range logs {
+		total += math.Exp(l - maxLog)
+	}
+
+	scores := make([]ClassScore, len(classes))
+	for i, class := range classes {
+		scores[i] = ClassScore{Class: class, Probability: math.Exp(logs[i]-maxLog) / total}
+	}
+	sort.Slice(scores, func(i, j int) bool {
+		if scores[i].Probability != scores[j].Probability {
+			return scores[i].Probability > scores[j].Probability
+		}
+		return scores[i].Class < scores[j].Class
+	})
+	return scores
+}
@@ -0,0 +1,27 @@
+package models
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// Backtest is the stored score of one season predicted by a model trained on
+// the seasons before it. Runs are grouped by model version so versions can be
+// compared over time.
+type Backtest struct {
+	ID           uint            `json:"id" gorm:"primaryKey"`
+	ModelVersion string          `json:"model_version" gorm:"index"`
+	RunAt        time.Time       `json:"run_at"`
+	Season       int             `json:"season"`
+	TrainingSize int             `json:"training_size"`
+	Deals        int             `json:"deals"`
+	Accuracy     float64         `json:"accuracy"`
+	BrierScore   float64         `json:"brier_score"`
+	AUC          float64         `json:"auc"`
+	Calibration  json.RawMessage `json:"calibration" gorm:"type:text"`
+	Industries   json.RawMessage `json:"industries" gorm:"type:text"`
+}
+
+func (Backtest) TableName() string {
+	return "backtests"
+}
@@ -1,6 +1,9 @@
 package models
 
 import (
+	"database/sql/driver"
+	"fmt"
+	"strings"
 	"time"
 )
 
@@ -17,17 +20,20 @@ type Deal struct {
 	DealEquity      float64   `json:"deal_equity"`
 	DealDebt        float64   `json:"deal_debt"`
 	MultipleSharks  bool      `json:"multiple_sharks"`
-	InterestedSharks []string `json:"interested_sharks" gorm:"type:text[]"`
-	InvestedSharks  []string `json:"invested_sharks" gorm:"type:text[]"`
+	InterestedSharks SharkList `json:"interested_sharks" gorm:"type:text"`
+	InvestedSharks  SharkList `json:"invested_sharks" gorm:"type:text"`
 	SuccessStatus   string    `json:"success_status"`
 	PitchDescription string   `json:"pitch_description"`
 	ProductCategory string    `json:"product_category"`
-	RevenueCurrent  float64   `json:"revenue_current"`
+	RevenueCurrent  *float64  `json:"revenue_current"`
 	RevenueProjected float64  `json:"revenue_projected"`
-	ProfitMargin    float64   `json:"profit_margin"`
+	ProfitMargin    *float64  `json:"profit_margin"`
 	TeamSize        int       `json:"team_size"`
 	FoundedYear     int       `json:"founded_year"`
 	Location        string    `json:"location"`
+	City            string    `json:"city"`
+	State           string    `json:"state"`
+	CityTier        int       `json:"city_tier"`
 	PatentStatus    string    `json:"patent_status"`
 	OnlinePresence  struct {
 		Website    string `json:"website"`
@@ -36,22 +42,71 @@ type Deal struct {
 			Facebook  string `json:"facebook"`
 			Twitter   string `json:"twitter"`
 		} `json:"social_media"`
-	} `json:"online_presence"`
-	PostShowStatus struct {
-		RevenueGrowth  float64   `json:"revenue_growth"`
-		EmployeeGrowth float64   `json:"employee_growth"`
-		MarketExpansion []string `json:"market_expansion"`
-		FundingRounds  []struct {
-			Round     string    `json:"round"`
-			Amount    float64   `json:"amount"`
-			Investors []string  `json:"investors"`
-			Date      time.Time `json:"date"`
-		} `json:"funding_rounds"`
-	} `json:"post_show_status"`
+	} `json:"online_presence" gorm:"-"`
+	PostShowStatus  PostShowStatus `json:"post_show_status" gorm:"serializer:json"`
+	Founders        []Founder `json:"founders" gorm:"foreignKey:DealID"`
+	// AirDate is when the pitch aired, from its episode or, when only that is
+	// known, the start of its season (AirDateExact false). It is filled in when
+	// deals are loaded, not stored with the deal.
+	AirDate         *time.Time `json:"air_date,omitempty" gorm:"-"`
+	AirDateExact    bool      `json:"air_date_exact,omitempty" gorm:"-"`
+	// Panel are the sharks on the roster of the pitch's episode, empty when the
+	// roster is unknown. Like AirDate it is filled in when deals are loaded.
+	Panel           []string  `json:"panel,omitempty" gorm:"-"`
 	CreatedAt time.Time `json:"created_at"`
 	UpdatedAt time.Time `json:"updated_at"`
 }
 
 func (Deal) TableName() string {
 	return "deals"
-}
\ No newline at end of file
+}
+
+// PostShowStatus tracks how a startup did after its pitch aired
+type PostShowStatus struct {
+	RevenueGrowth   float64        `json:"revenue_growth"`
+	EmployeeGrowth  float64        `json:"employee_growth"`
+	MarketExpansion []string       `json:"market_expansion"`
+	FundingRounds   []FundingRound `json:"funding_rounds"`
+	RevenueHistory  []RevenueSnapshot `json:"revenue_history"`
+}
+
+// FundingRound is a round raised after the show. PostMoneyValuation is optional
+// and left at zero when the round's valuation was not disclosed.
+type FundingRound struct {
+	Round              string    `json:"round"`
+	Amount             float64   `json:"amount"`
+	PostMoneyValuation float64   `json:"post_money_valuation"`
+	Investors          []string  `json:"investors"`
+	Date               time.Time `json:"date"`
+}
+
+// RevenueSnapshot is the annual revenue of a startup as reported at a date
+// after the show
+type RevenueSnapshot struct {
+	Date    time.Time `json:"date"`
+	Revenue float64   `json:"revenue"`
+}
+
+// SharkList is a list of shark names, stored as the comma separated text the
+// spreadsheet import writes
+type SharkList []string
+
+// Scan implements sql.Scanner
+func (l *SharkList) Scan(value interface{}) error {
+	switch v := value.(type) {
+	case nil:
+		*l = nil
+	case string:
+		*l = strings.Split(v, ",")
+	case []byte:
+		*l = strings.Split(string(v), ",")
+	default:
+		return fmt.Errorf("cannot scan %T into SharkList", value)
+	}
+	return nil
+}
+
+// Value implements driver.Valuer
+func (l SharkList) Value() (driver.Value, error) {
+	return strings.Join(l, ","), nil
+}
@@ -0,0 +1,21 @@
+package models
+
+import (
+	"time"
+)
+
+// Episode is an aired episode of a season. AirDate is a YYYY-MM-DD date, empty
+// until it is imported or entered by an editor.
+type Episode struct {
+	ID        uint      `json:"id" gorm:"primaryKey"`
+	Season    int       `json:"season" gorm:"uniqueIndex:idx_season_episode"`
+	Episode   int       `json:"episode" gorm:"uniqueIndex:idx_season_episode"`
+	Title     string    `json:"title"`
+	AirDate   string    `json:"air_date"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+func (Episode) TableName() string {
+	return "episodes"
+}
@@ -0,0 +1,22 @@
+package models
+
+import (
+	"time"
+)
+
+// Founder is one founder of a pitching startup. Gender is "male", "female" or
+// "other" and AgeBand one of "under-25", "25-34", "35-44" or "45+"; either may be
+// empty when unknown.
+type Founder struct {
+	ID            uint      `json:"id" gorm:"primaryKey"`
+	DealID        uint      `json:"deal_id" gorm:"index"`
+	Name          string    `json:"name"`
+	Gender        string    `json:"gender"`
+	AgeBand       string    `json:"age_band"`
+	RepeatFounder bool      `json:"repeat_founder"`
+	CreatedAt     time.Time `json:"created_at"`
+}
+
+func (Founder) TableName() string {
+	return "founders"
+}
@@ -0,0 +1,26 @@
+package models
+
+import (
+	"time"
+)
+
+// DealLabel is the text classifier's suggested industry and product category
+// for a deal, next to the labels the deal was imported with
+type DealLabel struct {
+	ID                 uint    `json:"id" gorm:"primaryKey"`
+	DealID             uint    `json:"deal_id" gorm:"uniqueIndex"`
+	SuggestedIndustry  string  `json:"suggested_industry"`
+	IndustryConfidence float64 `json:"industry_confidence"`
+	IndustryMislabeled bool    `json:"industry_mislabeled"`
+	// Set when the deal's industry was empty and filled in from the suggestion
+	IndustryFilled     bool      `json:"industry_filled"`
+	SuggestedCategory  string    `json:"suggested_category"`
+	CategoryConfidence float64   `json:"category_confidence"`
+	CategoryMislabeled bool      `json:"category_mislabeled"`
+	CategoryFilled     bool      `json:"category_filled"`
+	CreatedAt          time.Time `json:"created_at"`
+}
+
+func (DealLabel) TableName() string {
+	return "deal_labels"
+}
@@ -0,0 +1,25 @@
+package models
+
+import (
+	"time"
+)
+
+// Prediction is a stored model prediction for a deal, stamped with the version
+// of the model that produced it
+type Prediction struct {
+	ID                 uint      `json:"id" gorm:"primaryKey"`
+	DealID             uint      `json:"deal_id" gorm:"index"`
+	SuccessProbability float64   `json:"success_probability"`
+	PredictedEquity    float64   `json:"predicted_equity"`
+	PredictedAmount    float64   `json:"predicted_amount"`
+	PredictedValuation float64   `json:"predicted_valuation"`
+	ValuationLow       float64   `json:"valuation_low"`
+	ValuationHigh      float64   `json:"valuation_high"`
+	RiskScore          int       `json:"risk_score"`
+	ModelVersion       string    `json:"model_version" gorm:"index"`
+	CreatedAt          time.Time `json:"created_at"`
+}
+
+func (Prediction) TableName() string {
+	return "predictions"
+}
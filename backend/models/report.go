@@ -0,0 +1,36 @@
+package models
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// ReportDefinition is the analytics query behind a saved report, using the
+// dimension and metric names of /api/analytics
+type ReportDefinition struct {
+	Filters map[string]string `json:"filters"`
+	GroupBy []string          `json:"group_by"`
+	Metrics []string          `json:"metrics"`
+}
+
+// Report is a named analytics query saved by a user. Scheduled reports are
+// recomputed on their schedule ("hourly", "daily" or "weekly") and reports
+// with a share token can be read by anyone holding the token.
+type Report struct {
+	ID         uint             `json:"id" gorm:"primaryKey"`
+	UserID     int64            `json:"user_id" gorm:"index"`
+	Name       string           `json:"name"`
+	Definition ReportDefinition `json:"definition" gorm:"serializer:json"`
+	ChartType  string           `json:"chart_type"`
+	Schedule   string           `json:"schedule"`
+	ShareToken string           `json:"share_token,omitempty" gorm:"uniqueIndex"`
+	LastResult json.RawMessage  `json:"last_result,omitempty" gorm:"type:text"`
+	LastRunAt  *time.Time       `json:"last_run_at"`
+	NextRunAt  *time.Time       `json:"next_run_at"`
+	CreatedAt  time.Time        `json:"created_at"`
+	UpdatedAt  time.Time        `json:"updated_at"`
+}
+
+func (Report) TableName() string {
+	return "reports"
+}
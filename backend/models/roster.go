@@ -0,0 +1,20 @@
+package models
+
+import (
+	"time"
+)
+
+// SharkAppearance records a shark sitting on the panel of a single episode
+type SharkAppearance struct {
+	ID        uint      `json:"id" gorm:"primaryKey"`
+	SharkID   string    `json:"shark_id" gorm:"index"`
+	SharkName string    `json:"shark_name"`
+	Season    int       `json:"season" gorm:"index"`
+	Episode   int       `json:"episode"`
+	Guest     bool      `json:"guest"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+func (SharkAppearance) TableName() string {
+	return "shark_appearances"
+}
@@ -0,0 +1,27 @@
+package models
+
+import (
+	"time"
+)
+
+// Season is a summary of one season, recomputed from its deals after every
+// import. Start and end dates are entered by hand and survive recomputation.
+type Season struct {
+	ID               uint       `json:"id" gorm:"primaryKey"`
+	SeasonNumber     int        `json:"season_number" gorm:"uniqueIndex"`
+	StartDate        *time.Time `json:"start_date"`
+	EndDate          *time.Time `json:"end_date"`
+	TotalEpisodes    int        `json:"total_episodes"`
+	TotalPitches     int        `json:"total_pitches"`
+	TotalDeals       int        `json:"total_deals"`
+	TotalInvestment  float64    `json:"total_investment"`
+	AverageValuation float64    `json:"average_valuation"`
+	MedianValuation  float64    `json:"median_valuation"`
+	SuccessRate      float64    `json:"success_rate"`
+	CreatedAt        time.Time  `json:"created_at"`
+	UpdatedAt        time.Time  `json:"updated_at"`
+}
+
+func (Season) TableName() string {
+	return "seasons"
+}
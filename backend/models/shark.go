@@ -4,38 +4,41 @@ import (
 	"time"
 )
 
+// Shark is a shark profile managed by editors. Deal totals and season
+// appearances are not stored; they are computed from deals and the roster.
 type Shark struct {
 	ID               string    `json:"id" gorm:"primaryKey"`
 	Name             string    `json:"name"`
 	Title            string    `json:"title"`
 	Company          string    `json:"company"`
-	TotalDeals       int       `json:"total_deals"`
-	TotalInvestment  float64   `json:"total_investment"`
-	AverageEquity    float64   `json:"average_equity"`
+	TotalDeals       int       `json:"total_deals" gorm:"-"`
+	TotalInvestment  float64   `json:"total_investment" gorm:"-"`
+	AverageEquity    float64   `json:"average_equity" gorm:"-"`
 	SuccessfulExits  int       `json:"successful_exits"`
-	IndustryPreference []string `json:"industry_preference" gorm:"type:text[]"`
+	IndustryPreference []string `json:"industry_preference" gorm:"serializer:json"`
 	InvestmentRange  struct {
 		Min float64 `json:"min"`
 		Max float64 `json:"max"`
-	} `json:"investment_range"`
-	NotableInvestments []string  `json:"notable_investments" gorm:"type:text[]"`
-	InvestmentStyle   []string  `json:"investment_style" gorm:"type:text[]"`
-	SeasonAppearances []int     `json:"season_appearances" gorm:"type:integer[]"`
+	} `json:"investment_range" gorm:"serializer:json"`
+	NotableInvestments []string  `json:"notable_investments" gorm:"serializer:json"`
+	InvestmentStyle   []string  `json:"investment_style" gorm:"serializer:json"`
+	SeasonAppearances []int     `json:"season_appearances" gorm:"-"`
 	Bio              string    `json:"bio"`
-	Expertise        []string  `json:"expertise" gorm:"type:text[]"`
-	Education        []string  `json:"education" gorm:"type:text[]"`
-	Achievements     []string  `json:"achievements" gorm:"type:text[]"`
+	ProfileImage     string    `json:"profile_image"`
+	Expertise        []string  `json:"expertise" gorm:"serializer:json"`
+	Education        []string  `json:"education" gorm:"serializer:json"`
+	Achievements     []string  `json:"achievements" gorm:"serializer:json"`
 	SocialMedia      struct {
 		Twitter   string `json:"twitter"`
 		LinkedIn  string `json:"linkedin"`
 		Instagram string `json:"instagram"`
-	} `json:"social_media"`
+	} `json:"social_media" gorm:"serializer:json"`
 	InvestmentStats struct {
 		ByIndustry    map[string]float64 `json:"by_industry"`
 		ByStage       map[string]float64 `json:"by_stage"`
 		SuccessRate   float64            `json:"success_rate"`
 		AverageReturn float64            `json:"average_return"`
-	} `json:"investment_stats"`
+	} `json:"investment_stats" gorm:"serializer:json"`
 	CreatedAt time.Time `json:"created_at"`
 	UpdatedAt time.Time `json:"updated_at"`
 }
@@ -0,0 +1,221 @@
+package main
+
+import (
+	"math"
+	"net/http"
+	"sort"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/your-username/shark-tank-analytics/ml"
+	"github.com/your-username/shark-tank-analytics/models"
+	"github.com/your-username/shark-tank-analytics/stats"
+)
+
+// Groups with fewer multiples than this are judged against all pitches
+const minNormMultiples = 5
+
+// Default Tukey fence, in interquartile ranges above the upper quartile
+const defaultOutlierFence = 1.5
+
+// askMultiple is the asked valuation over current revenue
+func askMultiple(deal models.Deal) (float64, bool) {
+	return ratio(ml.AskValuation(deal), ml.Revenue(deal))
+}
+
+// forwardMultiple is the asked valuation over projected revenue
+func forwardMultiple(deal models.Deal) (float64, bool) {
+	return ratio(ml.AskValuation(deal), deal.RevenueProjected)
+}
+
+// acceptedMultiple is the valuation a shark agreed to over current revenue
+func acceptedMultiple(deal models.Deal) (float64, bool) {
+	if !ml.Funded(deal) {
+		return 0, false
+	}
+	return ratio(ml.DealValuation(deal), ml.Revenue(deal))
+}
+
+// getMultiples compares valuation-to-revenue multiples asked for and accepted,
+// grouped by industry or any other analytics dimension, e.g.
+// /api/analytics/multiples?group_by=shark&season=2.
+//
+// A pitch is flagged as an outlier when its ask multiple lies above the upper
+// Tukey fence of its group, Q3 + fence * IQR, with fence=1.5 by default. The
+// fence is computed on log multiples since multiples are heavily skewed, and
+// groups with too few multiples use the fence of all pitches.
+func getMultiples(c *gin.Context) {
+	dimension, ok := findDimension(c.DefaultQuery("group_by", "industry"))
+	if !ok {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "unknown group_by dimension"})
+		return
+	}
+	fence, err := strconv.ParseFloat(c.DefaultQuery("fence", strconv.FormatFloat(defaultOutlierFence, 'f', -1, 64)), 64)
+	if err != nil || fence <= 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "fence must be a positive number"})
+		return
+	}
+
+	deals, err := loadDeals("")
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	deals, filters := filterDeals(c, deals)
+
+	groups := make(map[string][]models.Deal)
+	for _, deal := range deals {
+		for _, key := range dimension.keys(deal) {
+			groups[key] = append(groups[key], deal)
+		}
+	}
+
+	overallFence := upperFence(multiplesOf(deals, askMultiple), fence)
+
+	rows := make([]gin.H, 0, len(groups))
+	outliers := make([]gin.H, 0)
+	for _, key := range sortedGroupNames(groups) {
+		group := groups[key]
+		asks := multiplesOf(group, askMultiple)
+		accepted := multiplesOf(group, acceptedMultiple)
+
+		groupFence, normFrom := upperFence(asks, fence), "group"
+		if len(asks) < minNormMultiples {
+			groupFence, normFrom = overallFence, "all_pitches"
+		}
+		median := stats.Median(asks)
+
+		rows = append(rows, gin.H{
+			dimension.Name:       key,
+			"pitches":            len(group),
+			"ask_multiple":       stats.Summarize(asks),
+			"forward_multiple":   stats.Summarize(multiplesOf(group, forwardMultiple)),
+			"accepted_multiple":  stats.Summarize(accepted),
+			"acceptance_haircut": acceptanceHaircut(group),
+			"outlier_threshold":  nullIfZero(groupFence),
+			"norm_from":          normFrom,
+		})
+
+		for _, deal := range group {
+			multiple, ok := askMultiple(deal)
+			if !ok || groupFence == 0 || multiple <= groupFence {
+				continue
+			}
+			entry := gin.H{
+				dimension.Name:      key,
+				"deal_id":           deal.ID,
+				"startup_name":      deal.StartupName,
+				"season":            deal.Season,
+				"ask_multiple":      multiple,
+				"outlier_threshold": groupFence,
+				"funded":            ml.Funded(deal),
+			}
+			if median > 0 && normFrom == "group" {
+				entry["times_group_median"] = multiple / median
+			}
+			outliers = append(outliers, entry)
+		}
+	}
+
+	sort.SliceStable(outliers, func(i, j int) bool {
+		return outliers[i]["ask_multiple"].(float64) > outliers[j]["ask_multiple"].(float64)
+	})
+
+	c.JSON(http.StatusOK, gin.H{
+		"group_by": dimension.Name,
+		"filters":  filters,
+		"fence":    fence,
+		"groups":   rows,
+		"outliers": outliers,
+	})
+}
+
+// Helper functions
+
+func ratio(numerator, denominator float64) (float64, bool) {
+	if numerator <= 0 || denominator <= 0 {
+		return 0, false
+	}
+	return numerator / denominator, true
+}
+
+func multiplesOf(deals []models.Deal, multiple func(models.Deal) (float64, bool)) []float64 {
+	values := make([]float64, 0, len(deals))
+	for _, deal := range deals {
+		if m, ok := multiple(deal); ok {
+			values = append(values, m)
+		}
+	}
+	return values
+}
+
+// upperFence is the Tukey fence of multiples in log space, 0 without enough data
+func upperFence(multiples []float64, fence float64) float64 {
+	if len(multiples) < 4 {
+		return 0
+	}
+	logs := make([]float64, len(multiples))
+	for i, m := range multiples {
+		logs[i] = math.Log(m)
+	}
+	q1, q3 := stats.Quantile(logs, 0.25), stats.Quantile(logs, 0.75)
+	return math.Exp(q3 + fence*(q3-q1))
+}
+
+// acceptanceHaircut is the median share by which funded pitches' multiples were
+// cut between ask and deal, null without funded pitches that have a revenue
+func acceptanceHaircut(deals []models.Deal) interface{} {
+	haircuts := make([]float64, 0)
+	for _, deal := range deals {
+		asked, okAsk := askMultiple(deal)
+		accepted, okAccepted := acceptedMultiple(deal)
+		if okAsk && okAccepted {
+			haircuts = append(haircuts, 1-accepted/asked)
+		}
+	}
+	if len(haircuts) == 0 {
+		return nil
+	}
+	return stats.Median(haircuts)
+}
+
+func nullIfZero(v float64) interface{} {
+	if v == 0 {
+		return nil
+	}
+	return v
+}
+
+// revenueStage buckets a pitch by current revenue, as used by the analytics
+// dimensions. Pitches that did not report revenue are Unknown.
+func revenueStage(deal models.Deal) string {
+	if deal.RevenueCurrent == nil {
+		return "Unknown"
+	}
+	switch revenue := *deal.RevenueCurrent; {
+	case revenue <= 0:
+		return "pre-revenue"
+	case revenue < 1e7:
+		return "under 1 crore"
+	case revenue < 1e8:
+		return "1-10 crore"
+	default:
+		return "10 crore+"
+	}
+}
+
+// profitability labels a pitch by its profit margin, Unknown when it did not
+// report one
+func profitability(deal models.Deal) string {
+	if deal.ProfitMargin == nil {
+		return "Unknown"
+	}
+	switch margin := *deal.ProfitMargin; {
+	case margin > 0:
+		return "profitable"
+	case margin < 0:
+		return "loss-making"
+	default:
+		return "break-even"
+	}
+}
@@ -0,0 +1,466 @@
+package main
+
+import (
+	"crypto/rand"
+	"database/sql"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/your-username/shark-tank-analytics/models"
+)
+
+// How often each report schedule recomputes a report
+var reportSchedules = map[string]time.Duration{
+	"hourly": time.Hour,
+	"daily":  24 * time.Hour,
+	"weekly": 7 * 24 * time.Hour,
+}
+
+var reportChartTypes = []string{"table", "bar", "line", "pie", "area", "map"}
+
+// How often the scheduler looks for reports that are due
+const reportSchedulerInterval = time.Minute
+
+const reportColumns = `
+	id, user_id, name, definition, COALESCE(chart_type, ''), COALESCE(schedule, ''),
+	COALESCE(share_token, ''), COALESCE(last_result, ''), COALESCE(last_run_at, ''),
+	COALESCE(next_run_at, ''), COALESCE(created_at, ''), COALESCE(updated_at, '')
+`
+
+var errReportNotFound = errors.New("report not found")
+
+// reportInput is the editable part of a report
+type reportInput struct {
+	Name       string                  `json:"name"`
+	Definition models.ReportDefinition `json:"definition"`
+	ChartType  string                  `json:"chart_type"`
+	Schedule   string                  `json:"schedule"`
+}
+
+func listReports(c *gin.Context) {
+	userID, ok := currentUserID(c)
+	if !ok {
+		return
+	}
+
+	rows, err := db.Query(`SELECT `+reportColumns+` FROM reports WHERE user_id = ? ORDER BY id`, userID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	defer rows.Close()
+
+	reports := make([]models.Report, 0)
+	for rows.Next() {
+		report, err := scanReport(rows)
+		if err != nil {
+			log.Printf("Error scanning report: %v", err)
+			continue
+		}
+		// Results can be large, they are returned by the single report endpoint
+		report.LastResult = nil
+		reports = append(reports, report)
+	}
+
+	c.JSON(http.StatusOK, reports)
+}
+
+func createReport(c *gin.Context) {
+	userID, ok := currentUserID(c)
+	if !ok {
+		return
+	}
+
+	var input reportInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if err := validateReport(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	definition, _ := json.Marshal(input.Definition)
+	now := time.Now().UTC()
+	result, err := db.Exec(`
+		INSERT INTO reports (
+			user_id, name, definition, chart_type, schedule, next_run_at,
+			created_at, updated_at
+		) VALUES (?, ?, ?, ?, ?, ?, ?, ?)
+	`, userID, input.Name, string(definition), input.ChartType, input.Schedule,
+		nextReportRun(input.Schedule, now), formatReportTime(now), formatReportTime(now))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	id, _ := result.LastInsertId()
+	report, err := loadReport("id = ? AND user_id = ?", id, userID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, report)
+}
+
+func getReport(c *gin.Context) {
+	report, ok := ownReport(c)
+	if !ok {
+		return
+	}
+
+	c.JSON(http.StatusOK, report)
+}
+
+func updateReport(c *gin.Context) {
+	report, ok := ownReport(c)
+	if !ok {
+		return
+	}
+
+	var input reportInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if err := validateReport(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	// A changed query makes the stored result stale
+	definition, _ := json.Marshal(input.Definition)
+	now := time.Now().UTC()
+	_, err := db.Exec(`
+		UPDATE reports SET
+			name = ?, definition = ?, chart_type = ?, schedule = ?, next_run_at = ?,
+			last_result = NULL, last_run_at = NULL, updated_at = ?
+		WHERE id = ?
+	`, input.Name, string(definition), input.ChartType, input.Schedule,
+		nextReportRun(input.Schedule, now), formatReportTime(now), report.ID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	updated, err := loadReport("id = ?", report.ID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, updated)
+}
+
+func deleteReport(c *gin.Context) {
+	report, ok := ownReport(c)
+	if !ok {
+		return
+	}
+
+	if _, err := db.Exec(`DELETE FROM reports WHERE id = ?`, report.ID); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Report deleted"})
+}
+
+// runReport recomputes a report now and stores the result
+func runReport(c *gin.Context) {
+	report, ok := ownReport(c)
+	if !ok {
+		return
+	}
+
+	if err := executeReport(&report); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, report)
+}
+
+// shareReport creates the read-only link token of a report, keeping an
+// existing one so links already handed out keep working
+func shareReport(c *gin.Context) {
+	report, ok := ownReport(c)
+	if !ok {
+		return
+	}
+
+	if report.ShareToken == "" {
+		token, err := newShareToken()
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+		if _, err := db.Exec(`UPDATE reports SET share_token = ? WHERE id = ?`, token, report.ID); err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+		report.ShareToken = token
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"share_token": report.ShareToken,
+		"path":        "/api/shared/reports/" + report.ShareToken,
+	})
+}
+
+// unshareReport revokes the read-only link of a report
+func unshareReport(c *gin.Context) {
+	report, ok := ownReport(c)
+	if !ok {
+		return
+	}
+
+	if _, err := db.Exec(`UPDATE reports SET share_token = NULL WHERE id = ?`, report.ID); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Report is no longer shared"})
+}
+
+// getSharedReport returns a shared report to anyone with its token. The
+// owner and schedule are left out, and a report that never ran is computed.
+func getSharedReport(c *gin.Context) {
+	report, err := loadReport("share_token = ?", c.Param("token"))
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Report not found"})
+		return
+	}
+
+	if report.LastResult == nil {
+		if err := executeReport(&report); err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"name":        report.Name,
+		"definition":  report.Definition,
+		"chart_type":  report.ChartType,
+		"last_run_at": report.LastRunAt,
+		"result":      report.LastResult,
+	})
+}
+
+// runReportScheduler recomputes scheduled reports as they fall due
+func runReportScheduler() {
+	ticker := time.NewTicker(reportSchedulerInterval)
+	defer ticker.Stop()
+
+	for range ticker.C {
+		reports, err := dueReports(time.Now().UTC())
+		if err != nil {
+			log.Printf("Error loading scheduled reports: %v", err)
+			continue
+		}
+		for i := range reports {
+			if err := executeReport(&reports[i]); err != nil {
+				log.Printf("Error running report %d: %v", reports[i].ID, err)
+			}
+		}
+	}
+}
+
+// Helper functions
+
+// currentUserID reads the user id the auth middleware took from the JWT
+func currentUserID(c *gin.Context) (int64, bool) {
+	value, _ := c.Get("user_id")
+	switch id := value.(type) {
+	case float64:
+		return int64(id), true
+	case int64:
+		return id, true
+	}
+	c.JSON(http.StatusUnauthorized, gin.H{"error": "Invalid token"})
+	return 0, false
+}
+
+// ownReport loads the report in the URL if it belongs to the current user.
+// Other users' reports are reported as missing rather than forbidden.
+func ownReport(c *gin.Context) (models.Report, bool) {
+	userID, ok := currentUserID(c)
+	if !ok {
+		return models.Report{}, false
+	}
+
+	report, err := loadReport("id = ? AND user_id = ?", c.Param("id"), userID)
+	if err == errReportNotFound {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Report not found"})
+		return report, false
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return report, false
+	}
+	return report, true
+}
+
+func validateReport(input *reportInput) error {
+	input.Name = strings.TrimSpace(input.Name)
+	if input.Name == "" {
+		return errors.New("name is required")
+	}
+	if input.ChartType == "" {
+		input.ChartType = "table"
+	}
+	if !containsFold(reportChartTypes, input.ChartType) {
+		return errors.New("chart_type must be one of " + strings.Join(reportChartTypes, ", "))
+	}
+	if _, ok := reportSchedules[input.Schedule]; input.Schedule != "" && !ok {
+		return errors.New("schedule must be hourly, daily, weekly or empty")
+	}
+
+	// Reject queries the analytics engine would
+	if _, err := analyticsDimensionsFor(strings.Join(input.Definition.GroupBy, ",")); err != nil {
+		return err
+	}
+	if _, err := analyticsMetricsFor(strings.Join(input.Definition.Metrics, ",")); err != nil {
+		return err
+	}
+	for name := range input.Definition.Filters {
+		if _, ok := findDimension(name); !ok {
+			return errors.New("unknown filter " + name)
+		}
+	}
+	return nil
+}
+
+// executeReport runs a report's query and stores the result and next run time
+func executeReport(report *models.Report) error {
+	d := report.Definition
+	result, err := runAnalytics(d.Filters, d.GroupBy, d.Metrics)
+	if err == errInvalidQuery {
+		return errors.New(result["error"].(string))
+	}
+	if err != nil {
+		return err
+	}
+
+	data, err := json.Marshal(result)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now().UTC()
+	next := nextReportRun(report.Schedule, now)
+	_, err = db.Exec(`
+		UPDATE reports SET last_result = ?, last_run_at = ?, next_run_at = ? WHERE id = ?
+	`, string(data), formatReportTime(now), next, report.ID)
+	if err != nil {
+		return err
+	}
+
+	report.LastResult = data
+	report.LastRunAt = &now
+	report.NextRunAt = parseReportTime(next.String)
+	return nil
+}
+
+func loadReport(where string, args ...interface{}) (models.Report, error) {
+	row := db.QueryRow(`SELECT `+reportColumns+` FROM reports WHERE `+where, args...)
+	report, err := scanReport(row)
+	if err == sql.ErrNoRows {
+		return report, errReportNotFound
+	}
+	return report, err
+}
+
+func dueReports(now time.Time) ([]models.Report, error) {
+	rows, err := db.Query(`
+		SELECT `+reportColumns+` FROM reports
+		WHERE schedule != '' AND next_run_at IS NOT NULL AND next_run_at <= ?
+	`, formatReportTime(now))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var reports []models.Report
+	for rows.Next() {
+		report, err := scanReport(rows)
+		if err != nil {
+			return nil, err
+		}
+		reports = append(reports, report)
+	}
+	return reports, rows.Err()
+}
+
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func scanReport(row rowScanner) (models.Report, error) {
+	var report models.Report
+	var definition, lastResult, lastRunAt, nextRunAt, createdAt, updatedAt string
+
+	err := row.Scan(
+		&report.ID, &report.UserID, &report.Name, &definition, &report.ChartType,
+		&report.Schedule, &report.ShareToken, &lastResult, &lastRunAt, &nextRunAt,
+		&createdAt, &updatedAt,
+	)
+	if err != nil {
+		return report, err
+	}
+
+	if err := json.Unmarshal([]byte(definition), &report.Definition); err != nil {
+		return report, err
+	}
+	if lastResult != "" {
+		report.LastResult = json.RawMessage(lastResult)
+	}
+	report.LastRunAt = parseReportTime(lastRunAt)
+	report.NextRunAt = parseReportTime(nextRunAt)
+	if t := parseReportTime(createdAt); t != nil {
+		report.CreatedAt = *t
+	}
+	if t := parseReportTime(updatedAt); t != nil {
+		report.UpdatedAt = *t
+	}
+	return report, nil
+}
+
+// Report times are stored as RFC 3339 UTC text so they compare correctly as strings
+func formatReportTime(t time.Time) string {
+	return t.UTC().Format(time.RFC3339)
+}
+
+func parseReportTime(s string) *time.Time {
+	t, err := time.Parse(time.RFC3339, s)
+	if err != nil {
+		return nil
+	}
+	return &t
+}
+
+// nextReportRun is when a scheduled report is next due, NULL for unscheduled ones
+func nextReportRun(schedule string, from time.Time) sql.NullString {
+	interval, ok := reportSchedules[schedule]
+	if !ok {
+		return sql.NullString{}
+	}
+	return sql.NullString{String: formatReportTime(from.Add(interval)), Valid: true}
+}
+
+func newShareToken() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
@@ -0,0 +1,174 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/your-username/shark-tank-analytics/models"
+)
+
+const (
+	owner    int64 = 1
+	stranger int64 = 2
+)
+
+// decodeReport reads a report from a response body
+func decodeReport(t *testing.T, body []byte) models.Report {
+	t.Helper()
+	var report models.Report
+	if err := json.Unmarshal(body, &report); err != nil {
+		t.Fatal(err)
+	}
+	return report
+}
+
+func TestReportRoundTrip(t *testing.T) {
+	setupTestDB(t)
+	insertDeal(t, testDeal{Name: "Skippi", Industry: "Food", Season: 1, Episode: 1})
+	insertDeal(t, testDeal{Name: "Bummer", Industry: "Apparel", Season: 1, Episode: 2})
+
+	input := gin.H{
+		"name":       " Deals by industry ",
+		"definition": gin.H{"group_by": []string{"industry"}, "metrics": []string{"count", "deal_rate"}},
+		"schedule":   "daily",
+	}
+	rec := serveAs(t, owner, createReport, http.MethodPost, "/reports", "/reports", input)
+	expectStatus(t, rec, http.StatusCreated)
+	created := decodeReport(t, rec.Body.Bytes())
+	if created.Name != "Deals by industry" || created.ChartType != "table" || created.UserID != owner || created.NextRunAt == nil {
+		t.Errorf("created report = %+v, want a trimmed name, the default chart and a next run", created)
+	}
+
+	target := "/reports/" + strconv.FormatUint(uint64(created.ID), 10)
+	rec = serveAs(t, owner, getReport, http.MethodGet, "/reports/:id", target, nil)
+	expectStatus(t, rec, http.StatusOK)
+	if got := decodeReport(t, rec.Body.Bytes()); len(got.Definition.GroupBy) != 1 || got.Definition.GroupBy[0] != "industry" {
+		t.Errorf("stored definition = %+v, want grouping by industry", got.Definition)
+	}
+
+	// Running stores the result, and changing the query drops it again
+	rec = serveAs(t, owner, runReport, http.MethodPost, "/reports/:id/run", target+"/run", nil)
+	expectStatus(t, rec, http.StatusOK)
+	var result struct {
+		Groups []map[string]interface{} `json:"groups"`
+	}
+	if err := json.Unmarshal(decodeReport(t, rec.Body.Bytes()).LastResult, &result); err != nil || len(result.Groups) != 2 {
+		t.Errorf("run result has %d groups (%v), want one per industry", len(result.Groups), err)
+	}
+
+	input["chart_type"] = "bar"
+	input["schedule"] = ""
+	rec = serveAs(t, owner, updateReport, http.MethodPut, "/reports/:id", target, input)
+	expectStatus(t, rec, http.StatusOK)
+	updated := decodeReport(t, rec.Body.Bytes())
+	if updated.ChartType != "bar" || updated.NextRunAt != nil || updated.LastResult != nil {
+		t.Errorf("updated report = %+v, want a bar chart without schedule or stale result", updated)
+	}
+
+	// Other users see nothing
+	for _, request := range []struct {
+		handler gin.HandlerFunc
+		method  string
+		route   string
+	}{
+		{getReport, http.MethodGet, "/reports/:id"},
+		{updateReport, http.MethodPut, "/reports/:id"},
+		{deleteReport, http.MethodDelete, "/reports/:id"},
+		{shareReport, http.MethodPost, "/reports/:id"},
+	} {
+		expectStatus(t, serveAs(t, stranger, request.handler, request.method, request.route, target, input), http.StatusNotFound)
+	}
+	rec = serveAs(t, stranger, listReports, http.MethodGet, "/reports", "/reports", nil)
+	expectStatus(t, rec, http.StatusOK)
+	if rec.Body.String() != "[]" {
+		t.Errorf("stranger lists %s, want no reports", rec.Body.String())
+	}
+
+	rec = serveAs(t, owner, deleteReport, http.MethodDelete, "/reports/:id", target, nil)
+	expectStatus(t, rec, http.StatusOK)
+	expectStatus(t, serveAs(t, owner, getReport, http.MethodGet, "/reports/:id", target, nil), http.StatusNotFound)
+}
+
+func TestReportSharing(t *testing.T) {
+	setupTestDB(t)
+	insertDeal(t, testDeal{Name: "Skippi", Industry: "Food", Season: 1, Episode: 1})
+
+	input := gin.H{"name": "All pitches", "definition": gin.H{"metrics": []string{"count"}}}
+	rec := serveAs(t, owner, createReport, http.MethodPost, "/reports", "/reports", input)
+	expectStatus(t, rec, http.StatusCreated)
+	target := "/reports/" + strconv.FormatUint(uint64(decodeReport(t, rec.Body.Bytes()).ID), 10) + "/share"
+
+	var shared struct {
+		ShareToken string `json:"share_token"`
+		Path       string `json:"path"`
+	}
+	rec = serveAs(t, owner, shareReport, http.MethodPost, "/reports/:id/share", target, nil)
+	expectStatus(t, rec, http.StatusOK)
+	if err := json.Unmarshal(rec.Body.Bytes(), &shared); err != nil || len(shared.ShareToken) != 32 {
+		t.Fatalf("share response = %s, want a 32 character token", rec.Body.String())
+	}
+
+	// Sharing again keeps the link that was handed out
+	rec = serveAs(t, owner, shareReport, http.MethodPost, "/reports/:id/share", target, nil)
+	expectStatus(t, rec, http.StatusOK)
+	var again struct {
+		ShareToken string `json:"share_token"`
+	}
+	if err := json.Unmarshal(rec.Body.Bytes(), &again); err != nil {
+		t.Fatal(err)
+	}
+	if again.ShareToken != shared.ShareToken {
+		t.Errorf("token changed from %s to %s on sharing again", shared.ShareToken, again.ShareToken)
+	}
+
+	// Anyone with the token can read the report, computed on first view
+	rec = serveAs(t, 0, getSharedReport, http.MethodGet, "/shared/reports/:token", shared.Path[len("/api"):], nil)
+	expectStatus(t, rec, http.StatusOK)
+	var public map[string]json.RawMessage
+	if err := json.Unmarshal(rec.Body.Bytes(), &public); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := public["user_id"]; ok || string(public["result"]) == "null" {
+		t.Errorf("shared report = %s, want a result without the owner", rec.Body.String())
+	}
+
+	rec = serveAs(t, owner, unshareReport, http.MethodDelete, "/reports/:id/share", target, nil)
+	expectStatus(t, rec, http.StatusOK)
+	rec = serveAs(t, 0, getSharedReport, http.MethodGet, "/shared/reports/:token", "/shared/reports/"+shared.ShareToken, nil)
+	expectStatus(t, rec, http.StatusNotFound)
+}
+
+func TestCreateReportValidation(t *testing.T) {
+	setupTestDB(t)
+
+	definition := gin.H{"metrics": []string{"count"}}
+	tests := []struct {
+		name  string
+		input gin.H
+	}{
+		{"no name", gin.H{"name": " ", "definition": definition}},
+		{"unknown chart", gin.H{"name": "r", "chart_type": "radar", "definition": definition}},
+		{"unknown schedule", gin.H{"name": "r", "schedule": "monthly", "definition": definition}},
+		{"unknown dimension", gin.H{"name": "r", "definition": gin.H{"group_by": []string{"mood"}}}},
+		{"unknown metric", gin.H{"name": "r", "definition": gin.H{"metrics": []string{"vibes"}}}},
+		{"unknown filter", gin.H{"name": "r", "definition": gin.H{"filters": gin.H{"mood": "happy"}}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := serveAs(t, owner, createReport, http.MethodPost, "/reports", "/reports", tt.input)
+			expectStatus(t, rec, http.StatusBadRequest)
+		})
+	}
+
+	rec := serveAs(t, 0, createReport, http.MethodPost, "/reports", "/reports", gin.H{"name": "r"})
+	expectStatus(t, rec, http.StatusUnauthorized)
+
+	var count int
+	if err := db.QueryRow(`SELECT COUNT(*) FROM reports`).Scan(&count); err != nil || count != 0 {
+		t.Errorf("%d reports stored after rejected requests (%v), want none", count, err)
+	}
+}
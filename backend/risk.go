@@ -0,0 +1,58 @@
+package main
+
+import (
+	"math"
+	"os"
+
+	"github.com/your-username/shark-tank-analytics/risk"
+)
+
+// riskConfigPath returns the risk threshold file, overridable with RISK_CONFIG
+func riskConfigPath() string {
+	if path := os.Getenv("RISK_CONFIG"); path != "" {
+		return path
+	}
+	return "config/risk.json"
+}
+
+// riskSummary averages the risk scores of a group of deals. A nil summary,
+// for a group without deals, scores 0.
+type riskSummary struct {
+	count     int
+	total     int
+	risks     map[string]float64
+	riskCount map[string]int
+}
+
+func (s *riskSummary) add(result risk.Result) {
+	if s.risks == nil {
+		s.risks = make(map[string]float64)
+		s.riskCount = make(map[string]int)
+	}
+	s.count++
+	s.total += result.Score
+	for _, c := range result.Components {
+		s.risks[c.Name] += c.Risk
+		s.riskCount[c.Name]++
+	}
+}
+
+// score is the mean risk score between 0 and 100
+func (s *riskSummary) score() float64 {
+	if s == nil || s.count == 0 {
+		return 0
+	}
+	return math.Round(float64(s.total)/float64(s.count)*10) / 10
+}
+
+// components is the mean risk of each component over the deals that have it
+func (s *riskSummary) components() map[string]float64 {
+	means := make(map[string]float64)
+	if s == nil {
+		return means
+	}
+	for name, total := range s.risks {
+		means[name] = total / float64(s.riskCount[name])
+	}
+	return means
+}
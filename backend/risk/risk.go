@@ -0,0 +1,183 @@
+// Package risk computes a composite risk score for a pitch from its financials
+// and company profile.
+//
+// Each component maps a raw value onto a risk between 0 (safe) and 1 (risky)
+// by linear interpolation between two thresholds: values at or beyond Low get
+// risk 0, values at or beyond High get risk 1. Thresholds may run in either
+// direction, e.g. a high valuation multiple is risky but a high profit margin
+// is not. The score is
+//
+//	score = 100 * sum(weight_i * risk_i) / sum(weight_i)
+//
+// over the components the pitch has data for, so missing data neither raises
+// nor lowers the score. The components are:
+//
+//   - valuation_multiple: asked valuation / current revenue; pre-revenue pitches get full risk
+//   - profit_margin: profit margin in percent, skipped when not reported
+//   - team_size: number of employees
+//   - company_age: years from FoundedYear to the year the pitch aired, from the
+//     deal's air date or else the season years of the config; a hypothetical
+//     pitch without a season is aged as of the current year
+//   - patent: full risk without a patent, none with one
+//   - debt_share: DealDebt / DealAmount, the share of the deal given as debt
+//
+// debt_share needs deal terms, so it is skipped for hypothetical pitches and
+// pitches that got no deal, the same way company_age is skipped when the
+// founding year is unknown. Every other component uses only the pitch.
+package risk
+
+import (
+	"encoding/json"
+	"math"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/your-username/shark-tank-analytics/ml"
+	"github.com/your-username/shark-tank-analytics/models"
+)
+
+// Threshold is the range over which a component's risk goes from 0 to 1
+type Threshold struct {
+	Low    float64 `json:"low"`
+	High   float64 `json:"high"`
+	Weight float64 `json:"weight"`
+}
+
+// Config holds the thresholds of every component
+type Config struct {
+	ValuationMultiple Threshold `json:"valuation_multiple"`
+	ProfitMargin      Threshold `json:"profit_margin"`
+	TeamSize          Threshold `json:"team_size"`
+	CompanyAge        Threshold `json:"company_age"`
+	Patent            Threshold `json:"patent"`
+	DebtShare         Threshold `json:"debt_share"`
+	// SeasonYears maps a season number to the year it aired, for the company
+	// age of deals without an air date
+	SeasonYears map[string]int `json:"season_years"`
+}
+
+// DefaultConfig is used for any threshold the config file leaves out
+var DefaultConfig = Config{
+	ValuationMultiple: Threshold{Low: 5, High: 50, Weight: 3},
+	ProfitMargin:      Threshold{Low: 20, High: -20, Weight: 2},
+	TeamSize:          Threshold{Low: 20, High: 2, Weight: 1},
+	CompanyAge:        Threshold{Low: 5, High: 1, Weight: 1.5},
+	Patent:            Threshold{Low: 1, High: 0, Weight: 0.5},
+	DebtShare:         Threshold{Low: 0, High: 0.5, Weight: 1},
+	SeasonYears:       map[string]int{"1": 2021, "2": 2023, "3": 2024, "4": 2025},
+}
+
+// Component is the contribution of one factor to a risk score
+type Component struct {
+	Name   string  `json:"name"`
+	Value  float64 `json:"value"`
+	Risk   float64 `json:"risk"`
+	Weight float64 `json:"weight"`
+}
+
+// Result is a risk score between 0 and 100 with its components
+type Result struct {
+	Score      int         `json:"score"`
+	Components []Component `json:"components"`
+}
+
+// LoadConfig reads a JSON config file on top of DefaultConfig
+func LoadConfig(path string) (Config, error) {
+	cfg := DefaultConfig
+	cfg.SeasonYears = make(map[string]int)
+	for season, year := range DefaultConfig.SeasonYears {
+		cfg.SeasonYears[season] = year
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return cfg, err
+	}
+	if err := json.Unmarshal(data, &cfg); err != nil {
+		return DefaultConfig, err
+	}
+	return cfg, nil
+}
+
+// Score computes the risk score of a deal
+func Score(deal models.Deal, cfg Config) Result {
+	result := Result{Components: make([]Component, 0, 6)}
+
+	add := func(name string, value float64, t Threshold) {
+		result.Components = append(result.Components, Component{
+			Name:   name,
+			Value:  value,
+			Risk:   interpolate(value, t),
+			Weight: t.Weight,
+		})
+	}
+
+	valuation := ml.AskValuation(deal)
+	if valuation > 0 {
+		if revenue := ml.Revenue(deal); revenue > 0 {
+			add("valuation_multiple", valuation/revenue, cfg.ValuationMultiple)
+		} else {
+			// No revenue to justify any valuation
+			result.Components = append(result.Components, Component{
+				Name:   "valuation_multiple",
+				Risk:   1,
+				Weight: cfg.ValuationMultiple.Weight,
+			})
+		}
+	}
+
+	if deal.ProfitMargin != nil {
+		add("profit_margin", *deal.ProfitMargin, cfg.ProfitMargin)
+	}
+
+	if deal.TeamSize > 0 {
+		add("team_size", float64(deal.TeamSize), cfg.TeamSize)
+	}
+
+	year := cfg.SeasonYears[strconv.Itoa(deal.Season)]
+	if deal.AirDate != nil {
+		year = deal.AirDate.Year()
+	} else if deal.Season == 0 {
+		// A hypothetical pitch would air now
+		year = time.Now().Year()
+	}
+	if year > 0 && deal.FoundedYear > 0 {
+		add("company_age", math.Max(float64(year-deal.FoundedYear), 0), cfg.CompanyAge)
+	}
+
+	if strings.TrimSpace(deal.PatentStatus) != "" {
+		patent := 0.0
+		if ml.HasPatent(deal) {
+			patent = 1
+		}
+		add("patent", patent, cfg.Patent)
+	}
+
+	if deal.DealAmount > 0 {
+		add("debt_share", math.Min(deal.DealDebt/deal.DealAmount, 1), cfg.DebtShare)
+	}
+
+	total, weight := 0.0, 0.0
+	for _, c := range result.Components {
+		total += c.Weight * c.Risk
+		weight += c.Weight
+	}
+	if weight > 0 {
+		result.Score = int(math.Round(100 * total / weight))
+	}
+
+	return result
+}
+
+// interpolate maps a value onto a risk between 0 at t.Low and 1 at t.High
+func interpolate(value float64, t Threshold) float64 {
+	if t.High == t.Low {
+		if value >= t.High {
+			return 1
+		}
+		return 0
+	}
+	return math.Min(math.Max((value-t.Low)/(t.High-t.Low), 0), 1)
+}
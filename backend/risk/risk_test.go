@@ -0,0 +1,129 @@
+package risk
+
+import (
+	"testing"
+	"time"
+
+	"github.com/your-username/shark-tank-analytics/models"
+)
+
+func number(v float64) *float64 {
+	return &v
+}
+
+// components returns the components of a result by name
+func components(result Result) map[string]Component {
+	byName := make(map[string]Component, len(result.Components))
+	for _, c := range result.Components {
+		byName[c.Name] = c
+	}
+	return byName
+}
+
+func TestScoreProfitMargin(t *testing.T) {
+	tests := []struct {
+		name     string
+		margin   *float64
+		present  bool
+		wantRisk float64
+	}{
+		{"not reported", nil, false, 0},
+		{"break-even", number(0), true, 0.5},
+		{"profitable", number(25), true, 0},
+		{"loss-making", number(-30), true, 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			deal := models.Deal{RevenueCurrent: number(1e7), ProfitMargin: tt.margin}
+			c, ok := components(Score(deal, DefaultConfig))["profit_margin"]
+			if ok != tt.present {
+				t.Fatalf("profit_margin component present = %v, want %v", ok, tt.present)
+			}
+			if ok && c.Risk != tt.wantRisk {
+				t.Errorf("profit_margin risk = %v, want %v", c.Risk, tt.wantRisk)
+			}
+		})
+	}
+}
+
+func TestInterpolate(t *testing.T) {
+	rising := Threshold{Low: 10, High: 50}
+	falling := Threshold{Low: 20, High: -20}
+	tests := []struct {
+		value float64
+		t     Threshold
+		want  float64
+	}{
+		{0, rising, 0},
+		{10, rising, 0},
+		{30, rising, 0.5},
+		{80, rising, 1},
+		{40, falling, 0},
+		{0, falling, 0.5},
+		{-20, falling, 1},
+		{1, Threshold{Low: 1, High: 1}, 1},
+		{0, Threshold{Low: 1, High: 1}, 0},
+	}
+
+	for _, tt := range tests {
+		if got := interpolate(tt.value, tt.t); got != tt.want {
+			t.Errorf("interpolate(%v, %+v) = %v, want %v", tt.value, tt.t, got, tt.want)
+		}
+	}
+}
+
+func TestScoreDebtShare(t *testing.T) {
+	tests := []struct {
+		name     string
+		deal     models.Deal
+		present  bool
+		wantRisk float64
+	}{
+		{"hypothetical pitch", models.Deal{AskAmount: 5e6, AskEquity: 5}, false, 0},
+		{"all equity", models.Deal{DealAmount: 5e6}, true, 0},
+		{"quarter debt", models.Deal{DealAmount: 4e6, DealDebt: 1e6}, true, 0.5},
+		{"debt beyond the amount", models.Deal{DealAmount: 1e6, DealDebt: 3e6}, true, 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, ok := components(Score(tt.deal, DefaultConfig))["debt_share"]
+			if ok != tt.present {
+				t.Fatalf("debt_share component present = %v, want %v", ok, tt.present)
+			}
+			if ok && c.Risk != tt.wantRisk {
+				t.Errorf("debt_share risk = %v, want %v", c.Risk, tt.wantRisk)
+			}
+		})
+	}
+}
+
+func TestScoreCompanyAge(t *testing.T) {
+	aired := time.Date(2023, 3, 1, 0, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name    string
+		deal    models.Deal
+		present bool
+		wantAge float64
+	}{
+		{"founding year unknown", models.Deal{Season: 2}, false, 0},
+		{"dated by air date", models.Deal{FoundedYear: 2019, Season: 1, AirDate: &aired}, true, 4},
+		{"dated by season year", models.Deal{FoundedYear: 2020, Season: 3}, true, 4},
+		{"season without a year", models.Deal{FoundedYear: 2020, Season: 99}, false, 0},
+		{"hypothetical pitch", models.Deal{FoundedYear: 2020}, true, float64(time.Now().Year() - 2020)},
+		{"founded after airing", models.Deal{FoundedYear: 2030, Season: 1}, true, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, ok := components(Score(tt.deal, DefaultConfig))["company_age"]
+			if ok != tt.present {
+				t.Fatalf("company_age component present = %v, want %v", ok, tt.present)
+			}
+			if ok && c.Value != tt.wantAge {
+				t.Errorf("company_age = %v, want %v", c.Value, tt.wantAge)
+			}
+		})
+	}
+}
@@ -0,0 +1,34 @@
+package main
+
+import (
+	"log"
+)
+
+// userRoles are the roles a user can hold; editors may manage shark profiles,
+// rosters, episodes and founders
+var userRoles = map[string]bool{
+	"viewer": true,
+	"editor": true,
+}
+
+// assignRole implements the role subcommand: "role <email> <viewer|editor>"
+// sets the role of a registered user
+func assignRole(args []string) {
+	if len(args) != 2 {
+		log.Fatal("usage: role <email> <viewer|editor>")
+	}
+	email, role := args[0], args[1]
+	if !userRoles[role] {
+		log.Fatalf("Unknown role %q, expected viewer or editor", role)
+	}
+
+	result, err := db.Exec(`UPDATE users SET role = ? WHERE email = ?`, role, email)
+	if err != nil {
+		log.Fatal(err)
+	}
+	if count, _ := result.RowsAffected(); count == 0 {
+		log.Fatalf("No user registered with email %s", email)
+	}
+
+	log.Printf("Set role of %s to %s", email, role)
+}
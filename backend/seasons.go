@@ -0,0 +1,205 @@
+package main
+
+import (
+	"strings"
+	"time"
+
+	"github.com/your-username/shark-tank-analytics/ml"
+	"github.com/your-username/shark-tank-analytics/models"
+	"github.com/your-username/shark-tank-analytics/stats"
+)
+
+// refreshSeasons recomputes the seasons table from the stored deals. Seasons
+// without deals are removed; hand-entered dates of the others are kept.
+func refreshSeasons() error {
+	deals, err := loadDeals("")
+	if err != nil {
+		return err
+	}
+
+	bySeason := make(map[int][]models.Deal)
+	for _, deal := range deals {
+		bySeason[deal.Season] = append(bySeason[deal.Season], deal)
+	}
+
+	tx, err := db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	upsert, err := tx.Prepare(`
+		INSERT INTO seasons (
+			season_number, total_episodes, total_pitches, total_deals,
+			total_investment, average_valuation, median_valuation, success_rate
+		) VALUES (?, ?, ?, ?, ?, ?, ?, ?)
+		ON CONFLICT(season_number) DO UPDATE SET
+			total_episodes = excluded.total_episodes,
+			total_pitches = excluded.total_pitches,
+			total_deals = excluded.total_deals,
+			total_investment = excluded.total_investment,
+			average_valuation = excluded.average_valuation,
+			median_valuation = excluded.median_valuation,
+			success_rate = excluded.success_rate,
+			updated_at = CURRENT_TIMESTAMP
+	`)
+	if err != nil {
+		return err
+	}
+	defer upsert.Close()
+
+	for number, seasonDeals := range bySeason {
+		s := summarizeSeason(number, seasonDeals)
+		_, err := upsert.Exec(
+			s.SeasonNumber, s.TotalEpisodes, s.TotalPitches, s.TotalDeals,
+			s.TotalInvestment, s.AverageValuation, s.MedianValuation, s.SuccessRate,
+		)
+		if err != nil {
+			return err
+		}
+	}
+
+	if _, err := tx.Exec("DELETE FROM seasons WHERE season_number NOT IN (SELECT DISTINCT season FROM deals)"); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// summarizeSeason computes the stored statistics of one season
+func summarizeSeason(number int, deals []models.Deal) models.Season {
+	season := models.Season{SeasonNumber: number, TotalPitches: len(deals)}
+
+	episodes := make(map[int]bool)
+	valuations := make([]float64, 0, len(deals))
+	for _, deal := range deals {
+		episodes[deal.Episode] = true
+		if ml.Funded(deal) {
+			season.TotalDeals++
+			season.TotalInvestment += deal.DealAmount
+		}
+		if valuation := ml.AskValuation(deal); valuation > 0 {
+			valuations = append(valuations, valuation)
+		}
+	}
+
+	season.TotalEpisodes = len(episodes)
+	season.AverageValuation = stats.Mean(valuations)
+	season.MedianValuation = stats.Median(valuations)
+	if season.TotalPitches > 0 {
+		season.SuccessRate = float64(season.TotalDeals) / float64(season.TotalPitches)
+	}
+	return season
+}
+
+// attachAirDates sets when each deal aired: its episode's air date when that
+// is known, otherwise the start of its season, taken from the seasons table or
+// the earliest dated episode of the season
+func attachAirDates(deals []models.Deal) error {
+	episodes := make(map[[2]int]time.Time)
+	seasonStarts := make(map[int]time.Time)
+
+	rows, err := db.Query(`SELECT season, episode, air_date FROM episodes WHERE air_date != ''`)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var season, episode int
+		var airDate interface{}
+		if err := rows.Scan(&season, &episode, &airDate); err != nil {
+			return err
+		}
+		t, ok := storedDate(airDate)
+		if !ok {
+			continue
+		}
+		episodes[[2]int{season, episode}] = t
+		if start, ok := seasonStarts[season]; !ok || t.Before(start) {
+			seasonStarts[season] = t
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	// Hand-entered season start dates win over the earliest episode
+	seasonRows, err := db.Query(`SELECT season_number, start_date FROM seasons WHERE start_date IS NOT NULL`)
+	if err != nil {
+		return err
+	}
+	defer seasonRows.Close()
+
+	for seasonRows.Next() {
+		var season int
+		var startDate interface{}
+		if err := seasonRows.Scan(&season, &startDate); err != nil {
+			return err
+		}
+		if t, ok := storedDate(startDate); ok {
+			seasonStarts[season] = t
+		}
+	}
+	if err := seasonRows.Err(); err != nil {
+		return err
+	}
+
+	for i := range deals {
+		deal := &deals[i]
+		if t, ok := episodes[[2]int{deal.Season, deal.Episode}]; ok {
+			deal.AirDate, deal.AirDateExact = &t, true
+		} else if t, ok := seasonStarts[deal.Season]; ok {
+			deal.AirDate, deal.AirDateExact = &t, false
+		}
+	}
+	return nil
+}
+
+// attachPanels sets the sharks that sat on the panel of each deal's episode
+func attachPanels(deals []models.Deal) error {
+	rows, err := db.Query(`
+		SELECT season, episode, COALESCE(shark_name, '')
+		FROM shark_appearances
+		ORDER BY id
+	`)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	panels := make(map[[2]int][]string)
+	for rows.Next() {
+		var season, episode int
+		var name string
+		if err := rows.Scan(&season, &episode, &name); err != nil {
+			return err
+		}
+		if name = strings.TrimSpace(name); name != "" {
+			panels[[2]int{season, episode}] = append(panels[[2]int{season, episode}], name)
+		}
+	}
+
+	for i := range deals {
+		deals[i].Panel = panels[[2]int{deals[i].Season, deals[i].Episode}]
+	}
+	return rows.Err()
+}
+
+// storedDate reads a date column, which the driver returns as a time for
+// DATE columns and as text for TEXT columns or values it could not parse
+func storedDate(value interface{}) (time.Time, bool) {
+	switch v := value.(type) {
+	case time.Time:
+		return v, !v.IsZero()
+	case []byte:
+		return storedDate(string(v))
+	case string:
+		for _, layout := range []string{"2006-01-02", time.RFC3339, "2006-01-02 15:04:05"} {
+			if t, err := time.Parse(layout, strings.TrimSpace(v)); err == nil {
+				return t, true
+			}
+		}
+	}
+	return time.Time{}, false
+}
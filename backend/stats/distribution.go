@@ -0,0 +1,107 @@
+package stats
+
+import (
+	"math"
+	"sort"
+)
+
+// Summary describes the distribution of a set of values
+type Summary struct {
+	Count  int     `json:"count"`
+	Mean   float64 `json:"mean"`
+	StdDev float64 `json:"std_dev"`
+	Min    float64 `json:"min"`
+	P10    float64 `json:"p10"`
+	P25    float64 `json:"p25"`
+	Median float64 `json:"median"`
+	P75    float64 `json:"p75"`
+	P90    float64 `json:"p90"`
+	Max    float64 `json:"max"`
+}
+
+// Bucket is one histogram bar covering [Low, High), the last bucket also
+// includes High
+type Bucket struct {
+	Low   float64 `json:"low"`
+	High  float64 `json:"high"`
+	Count int     `json:"count"`
+}
+
+// Summarize computes the summary of values, all zero for no values
+func Summarize(values []float64) Summary {
+	if len(values) == 0 {
+		return Summary{}
+	}
+	sorted := append([]float64(nil), values...)
+	sort.Float64s(sorted)
+
+	return Summary{
+		Count:  len(sorted),
+		Mean:   Mean(sorted),
+		StdDev: StdDev(sorted),
+		Min:    sorted[0],
+		P10:    Quantile(sorted, 0.1),
+		P25:    Quantile(sorted, 0.25),
+		Median: Quantile(sorted, 0.5),
+		P75:    Quantile(sorted, 0.75),
+		P90:    Quantile(sorted, 0.9),
+		Max:    sorted[len(sorted)-1],
+	}
+}
+
+// LinearEdges splits [low, high] into n equal-width buckets
+func LinearEdges(low, high float64, n int) []float64 {
+	if n < 1 {
+		n = 1
+	}
+	edges := make([]float64, n+1)
+	for i := range edges {
+		edges[i] = low + (high-low)*float64(i)/float64(n)
+	}
+	return edges
+}
+
+// LogEdges splits [low, high] into n buckets of equal width on a log scale,
+// which suits money amounts spanning several orders of magnitude. Both bounds
+// must be positive.
+func LogEdges(low, high float64, n int) []float64 {
+	if low <= 0 || high <= 0 {
+		return LinearEdges(low, high, n)
+	}
+	edges := LinearEdges(math.Log10(low), math.Log10(high), n)
+	for i := range edges {
+		edges[i] = math.Pow(10, edges[i])
+	}
+	edges[0], edges[len(edges)-1] = low, high
+	return edges
+}
+
+// Histogram counts values into the buckets between consecutive edges, which
+// must be sorted. Values outside the edges are not counted.
+func Histogram(values, edges []float64) []Bucket {
+	if len(edges) < 2 {
+		return []Bucket{}
+	}
+	buckets := make([]Bucket, len(edges)-1)
+	for i := range buckets {
+		buckets[i] = Bucket{Low: edges[i], High: edges[i+1]}
+	}
+
+	last := len(buckets) - 1
+	for _, v := range values {
+		if v < edges[0] || v > edges[len(edges)-1] {
+			continue
+		}
+		// First edge above v, the bucket is the one before it
+		i := sort.SearchFloat64s(edges, v)
+		if i < len(edges) && edges[i] == v {
+			i++
+		}
+		i--
+		if i > last {
+			i = last
+		}
+		buckets[i].Count++
+	}
+	return buckets
+}
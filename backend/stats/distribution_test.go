@@ -0,0 +1,66 @@
+package stats
+
+import "testing"
+
+func TestSummarize(t *testing.T) {
+	if got := Summarize(nil); got != (Summary{}) {
+		t.Errorf("Summarize(nil) = %+v, want all zero", got)
+	}
+
+	values := []float64{9, 1, 5, 3, 7}
+	got := Summarize(values)
+	want := Summary{Count: 5, Mean: 5, Min: 1, P10: 1.8, P25: 3, Median: 5, P75: 7, P90: 8.2, Max: 9}
+	want.StdDev = got.StdDev
+	if got != want {
+		t.Errorf("Summarize = %+v, want %+v", got, want)
+	}
+	if !near(got.StdDev, 3.162278, 1e-6) {
+		t.Errorf("StdDev = %v, want 3.162278", got.StdDev)
+	}
+}
+
+func TestEdges(t *testing.T) {
+	tests := []struct {
+		name string
+		got  []float64
+		want []float64
+	}{
+		{"linear", LinearEdges(0, 10, 4), []float64{0, 2.5, 5, 7.5, 10}},
+		{"at least one bucket", LinearEdges(0, 1, 0), []float64{0, 1}},
+		{"log", LogEdges(1, 1000, 3), []float64{1, 10, 100, 1000}},
+		{"log of non-positive bounds", LogEdges(0, 4, 2), []float64{0, 2, 4}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if len(tt.got) != len(tt.want) {
+				t.Fatalf("edges = %v, want %v", tt.got, tt.want)
+			}
+			for i := range tt.got {
+				if !near(tt.got[i], tt.want[i], 1e-9) {
+					t.Fatalf("edges = %v, want %v", tt.got, tt.want)
+				}
+			}
+		})
+	}
+}
+
+func TestHistogram(t *testing.T) {
+	edges := []float64{0, 10, 20, 30}
+	values := []float64{-1, 0, 5, 10, 19.9, 20, 30, 31}
+
+	buckets := Histogram(values, edges)
+	want := []int{2, 2, 2}
+	if len(buckets) != len(want) {
+		t.Fatalf("got %d buckets, want %d", len(buckets), len(want))
+	}
+	for i, b := range buckets {
+		if b.Low != edges[i] || b.High != edges[i+1] || b.Count != want[i] {
+			t.Errorf("bucket %d = %+v, want [%v, %v) with %d values", i, b, edges[i], edges[i+1], want[i])
+		}
+	}
+
+	if got := Histogram(values, []float64{1}); len(got) != 0 {
+		t.Errorf("Histogram with one edge = %v, want no buckets", got)
+	}
+}
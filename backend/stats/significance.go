@@ -0,0 +1,232 @@
+package stats
+
+import (
+	"math"
+)
+
+// MinSampleSize is the number of observations below which a rate is flagged
+// as a small sample. With fewer than 10 pitches a single pitch moves a deal
+// rate by more than 10 points.
+const MinSampleSize = 10
+
+// Significance is the p-value below which a difference is called significant
+const Significance = 0.05
+
+// z-score of a two-sided 95% interval
+const z95 = 1.96
+
+// Rate is a proportion with its 95% Wilson score interval
+type Rate struct {
+	Value       float64 `json:"value"`
+	Low         float64 `json:"low"`
+	High        float64 `json:"high"`
+	Successes   int     `json:"successes"`
+	Total       int     `json:"total"`
+	SmallSample bool    `json:"small_sample"`
+}
+
+// NewRate returns successes/total with its Wilson interval. A rate over no
+// observations has the uninformative interval [0, 1].
+func NewRate(successes, total int) Rate {
+	r := Rate{Successes: successes, Total: total, SmallSample: total < MinSampleSize}
+	if total == 0 {
+		r.High = 1
+		return r
+	}
+	r.Value = float64(successes) / float64(total)
+	r.Low, r.High = Wilson(successes, total, z95)
+	return r
+}
+
+// Wilson returns the Wilson score interval of a proportion. Unlike the normal
+// approximation it stays within [0, 1] and behaves at rates of 0 and 1.
+func Wilson(successes, total int, z float64) (low, high float64) {
+	if total == 0 {
+		return 0, 1
+	}
+	n := float64(total)
+	p := float64(successes) / n
+	z2 := z * z
+
+	center := (p + z2/(2*n)) / (1 + z2/n)
+	margin := z / (1 + z2/n) * math.Sqrt(p*(1-p)/n+z2/(4*n*n))
+	return math.Max(center-margin, 0), math.Min(center+margin, 1)
+}
+
+// Test is the result of testing whether rates differ between groups
+type Test struct {
+	Method      string  `json:"method"`
+	Statistic   float64 `json:"statistic,omitempty"`
+	DF          int     `json:"df,omitempty"`
+	PValue      float64 `json:"p_value"`
+	Significant bool    `json:"significant"`
+	// Set when the chi-square approximation is doubtful because more than a
+	// fifth of the expected counts are below 5
+	Unreliable bool `json:"unreliable"`
+}
+
+// CompareRates tests whether the success rates of the groups differ. Two
+// groups with any expected count below 5 use Fisher's exact test, everything
+// else Pearson's chi-square test of independence. Groups without observations
+// are ignored, and fewer than two groups, or no variation at all, give a
+// p-value of 1.
+func CompareRates(successes, totals []int) Test {
+	var table [][2]float64
+	for i := range totals {
+		if totals[i] > 0 {
+			table = append(table, [2]float64{float64(successes[i]), float64(totals[i] - successes[i])})
+		}
+	}
+
+	if len(table) < 2 {
+		return Test{Method: "none", PValue: 1}
+	}
+
+	expected, small := expectedCounts(table)
+	if expected == nil {
+		return Test{Method: "none", PValue: 1}
+	}
+
+	if len(table) == 2 && small > 0 {
+		p := FisherExact(int(table[0][0]), int(table[0][1]), int(table[1][0]), int(table[1][1]))
+		return Test{Method: "fisher_exact", PValue: p, Significant: p < Significance}
+	}
+
+	statistic := 0.0
+	for i := range table {
+		for j := 0; j < 2; j++ {
+			d := table[i][j] - expected[i][j]
+			statistic += d * d / expected[i][j]
+		}
+	}
+	df := len(table) - 1
+	p := ChiSquareSurvival(statistic, df)
+
+	return Test{
+		Method:      "chi_square",
+		Statistic:   statistic,
+		DF:          df,
+		PValue:      p,
+		Significant: p < Significance,
+		Unreliable:  float64(small) > 0.2*float64(2*len(table)),
+	}
+}
+
+// expectedCounts returns the expected counts under independence and how many
+// are below 5, nil when a column is empty and nothing can be tested
+func expectedCounts(table [][2]float64) ([][2]float64, int) {
+	var columns [2]float64
+	total := 0.0
+	for _, row := range table {
+		columns[0] += row[0]
+		columns[1] += row[1]
+		total += row[0] + row[1]
+	}
+	if columns[0] == 0 || columns[1] == 0 {
+		return nil, 0
+	}
+
+	expected := make([][2]float64, len(table))
+	small := 0
+	for i, row := range table {
+		rowTotal := row[0] + row[1]
+		for j := 0; j < 2; j++ {
+			expected[i][j] = rowTotal * columns[j] / total
+			if expected[i][j] < 5 {
+				small++
+			}
+		}
+	}
+	return expected, small
+}
+
+// FisherExact returns the two-sided p-value of Fisher's exact test on the 2x2
+// table [[a, b], [c, d]]: the total probability of all tables with the same
+// margins that are no more likely than the observed one
+func FisherExact(a, b, c, d int) float64 {
+	row1, col1, n := a+b, a+c, a+b+c+d
+	observed := hypergeometric(a, row1, col1, n)
+
+	low := col1 - (n - row1)
+	if low < 0 {
+		low = 0
+	}
+	high := row1
+	if col1 < high {
+		high = col1
+	}
+
+	p := 0.0
+	for x := low; x <= high; x++ {
+		if q := hypergeometric(x, row1, col1, n); q <= observed*(1+1e-7) {
+			p += q
+		}
+	}
+	return math.Min(p, 1)
+}
+
+// hypergeometric is the probability of x successes in the first row of a 2x2
+// table with the given margins
+func hypergeometric(x, row1, col1, n int) float64 {
+	return math.Exp(logChoose(col1, x) + logChoose(n-col1, row1-x) - logChoose(n, row1))
+}
+
+func logChoose(n, k int) float64 {
+	a, _ := math.Lgamma(float64(n + 1))
+	b, _ := math.Lgamma(float64(k + 1))
+	c, _ := math.Lgamma(float64(n - k + 1))
+	return a - b - c
+}
+
+// ChiSquareSurvival returns P(X >= x) for a chi-square distribution with df
+// degrees of freedom
+func ChiSquareSurvival(x float64, df int) float64 {
+	if x <= 0 || df <= 0 {
+		return 1
+	}
+	return upperIncompleteGamma(float64(df)/2, x/2)
+}
+
+// upperIncompleteGamma is the regularized upper incomplete gamma function
+// Q(a, x), by its series for small x and its continued fraction otherwise
+func upperIncompleteGamma(a, x float64) float64 {
+	lg, _ := math.Lgamma(a)
+
+	if x < a+1 {
+		sum, term := 1/a, 1/a
+		for n := 1; n < 500; n++ {
+			term *= x / (a + float64(n))
+			sum += term
+			if math.Abs(term) < math.Abs(sum)*1e-14 {
+				break
+			}
+		}
+		return 1 - sum*math.Exp(-x+a*math.Log(x)-lg)
+	}
+
+	// Modified Lentz's method
+	const tiny = 1e-300
+	b := x + 1 - a
+	c := 1 / tiny
+	d := 1 / b
+	h := d
+	for i := 1; i < 500; i++ {
+		an := -float64(i) * (float64(i) - a)
+		b += 2
+		d = an*d + b
+		if math.Abs(d) < tiny {
+			d = tiny
+		}
+		c = b + an/c
+		if math.Abs(c) < tiny {
+			c = tiny
+		}
+		d = 1 / d
+		delta := d * c
+		h *= delta
+		if math.Abs(delta-1) < 1e-14 {
+			break
+		}
+	}
+	return math.Exp(-x+a*math.Log(x)-lg) * h
+}
@@ -0,0 +1,133 @@
+package stats
+
+import (
+	"math"
+	"testing"
+)
+
+func TestWilson(t *testing.T) {
+	tests := []struct {
+		successes, total  int
+		wantLow, wantHigh float64
+	}{
+		{0, 0, 0, 1},
+		{0, 10, 0, 0.277535},
+		{10, 10, 0.722465, 1},
+		{5, 10, 0.236590, 0.763410},
+		{30, 100, 0.218948, 0.395850},
+	}
+
+	for _, tt := range tests {
+		low, high := Wilson(tt.successes, tt.total, z95)
+		if !near(low, tt.wantLow, 1e-5) || !near(high, tt.wantHigh, 1e-5) {
+			t.Errorf("Wilson(%d, %d) = [%v, %v], want [%v, %v]",
+				tt.successes, tt.total, low, high, tt.wantLow, tt.wantHigh)
+		}
+	}
+}
+
+func TestNewRate(t *testing.T) {
+	tests := []struct {
+		name             string
+		successes, total int
+		wantValue        float64
+		wantSmall        bool
+	}{
+		{"no observations", 0, 0, 0, true},
+		{"small sample", 3, 9, 1.0 / 3, true},
+		{"large enough", 4, 10, 0.4, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := NewRate(tt.successes, tt.total)
+			if r.Value != tt.wantValue || r.SmallSample != tt.wantSmall {
+				t.Errorf("NewRate = %+v, want value %v and small sample %v", r, tt.wantValue, tt.wantSmall)
+			}
+			if r.Low > r.Value || r.High < r.Value || r.Low < 0 || r.High > 1 {
+				t.Errorf("interval [%v, %v] does not contain %v within [0, 1]", r.Low, r.High, r.Value)
+			}
+		})
+	}
+}
+
+func TestFisherExact(t *testing.T) {
+	tests := []struct {
+		a, b, c, d int
+		want       float64
+	}{
+		// Fisher's lady tasting tea
+		{3, 1, 1, 3, 0.485714},
+		{1, 9, 11, 3, 0.002759},
+		{5, 5, 5, 5, 1},
+		{0, 5, 5, 0, 0.007937},
+	}
+
+	for _, tt := range tests {
+		if got := FisherExact(tt.a, tt.b, tt.c, tt.d); !near(got, tt.want, 1e-5) {
+			t.Errorf("FisherExact(%d, %d, %d, %d) = %v, want %v", tt.a, tt.b, tt.c, tt.d, got, tt.want)
+		}
+	}
+}
+
+func TestChiSquareSurvival(t *testing.T) {
+	tests := []struct {
+		x    float64
+		df   int
+		want float64
+	}{
+		{0, 1, 1},
+		{3.841459, 1, 0.05},
+		{5.991465, 2, 0.05},
+		{2, 2, math.Exp(-1)},
+		{12.5, 1, 0.000407},
+		{30, 10, 0.000857},
+	}
+
+	for _, tt := range tests {
+		if got := ChiSquareSurvival(tt.x, tt.df); !near(got, tt.want, 1e-6) {
+			t.Errorf("ChiSquareSurvival(%v, %d) = %v, want %v", tt.x, tt.df, got, tt.want)
+		}
+	}
+}
+
+func TestCompareRates(t *testing.T) {
+	tests := []struct {
+		name            string
+		successes       []int
+		totals          []int
+		wantMethod      string
+		wantP           float64
+		wantSignificant bool
+	}{
+		{"one group", []int{3}, []int{10}, "none", 1, false},
+		{"empty groups ignored", []int{3, 0}, []int{10, 0}, "none", 1, false},
+		{"no variation", []int{0, 0}, []int{10, 20}, "none", 1, false},
+		{"chi-square", []int{10, 30}, []int{100, 100}, "chi_square", 0.000407, true},
+		{"no difference", []int{20, 20}, []int{100, 100}, "chi_square", 1, false},
+		{"small counts", []int{1, 5}, []int{10, 6}, "fisher_exact", 0.007617, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			test := CompareRates(tt.successes, tt.totals)
+			if test.Method != tt.wantMethod {
+				t.Fatalf("method = %q, want %q", test.Method, tt.wantMethod)
+			}
+			if !near(test.PValue, tt.wantP, 1e-5) || test.Significant != tt.wantSignificant {
+				t.Errorf("p = %v significant = %v, want %v and %v", test.PValue, test.Significant, tt.wantP, tt.wantSignificant)
+			}
+		})
+	}
+}
+
+func TestCompareRatesUnreliable(t *testing.T) {
+	// Three groups, so no exact test, with most expected successes below 5
+	test := CompareRates([]int{1, 2, 6}, []int{10, 10, 10})
+	if test.Method != "chi_square" || test.DF != 2 {
+		t.Fatalf("test = %+v, want chi-square with 2 degrees of freedom", test)
+	}
+	if !test.Unreliable {
+		t.Error("expected counts of 3 should mark the chi-square test unreliable")
+	}
+}
@@ -0,0 +1,56 @@
+package stats
+
+import "testing"
+
+func TestMeanAndStdDev(t *testing.T) {
+	tests := []struct {
+		name       string
+		values     []float64
+		wantMean   float64
+		wantStdDev float64
+	}{
+		{"no values", nil, 0, 0},
+		{"one value", []float64{4}, 4, 0},
+		{"several", []float64{2, 4, 4, 4, 5, 5, 7, 9}, 5, 2.138090},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Mean(tt.values); !near(got, tt.wantMean, 1e-9) {
+				t.Errorf("Mean = %v, want %v", got, tt.wantMean)
+			}
+			if got := StdDev(tt.values); !near(got, tt.wantStdDev, 1e-6) {
+				t.Errorf("StdDev = %v, want %v", got, tt.wantStdDev)
+			}
+		})
+	}
+}
+
+func TestQuantile(t *testing.T) {
+	values := []float64{40, 10, 30, 20}
+	tests := []struct {
+		q    float64
+		want float64
+	}{
+		{0, 10},
+		{0.25, 17.5},
+		{0.5, 25},
+		{0.9, 37},
+		{1, 40},
+	}
+
+	for _, tt := range tests {
+		if got := Quantile(values, tt.q); !near(got, tt.want, 1e-9) {
+			t.Errorf("Quantile(%v) = %v, want %v", tt.q, got, tt.want)
+		}
+	}
+	if values[0] != 40 {
+		t.Error("Quantile reordered its input")
+	}
+	if got := Median(nil); got != 0 {
+		t.Errorf("Median(nil) = %v, want 0", got)
+	}
+	if got := Median([]float64{3, 1, 2}); got != 2 {
+		t.Errorf("Median = %v, want 2", got)
+	}
+}
@@ -0,0 +1,182 @@
+package stats
+
+import "math"
+
+// Coverage of forecast prediction intervals
+const forecastLevel = 0.9
+
+// Forecast is a predicted value with a 90% prediction interval. Low and High
+// are nil when there are too few points to estimate the spread.
+type Forecast struct {
+	Value float64  `json:"value"`
+	Low   *float64 `json:"low"`
+	High  *float64 `json:"high"`
+}
+
+// LinearTrend fits y = intercept + slope * x by least squares
+func LinearTrend(x, y []float64) (intercept, slope float64) {
+	if len(x) == 0 {
+		return 0, 0
+	}
+	mx, my := Mean(x), Mean(y)
+	sxx, sxy := 0.0, 0.0
+	for i := range x {
+		sxx += (x[i] - mx) * (x[i] - mx)
+		sxy += (x[i] - mx) * (y[i] - my)
+	}
+	if sxx == 0 {
+		return my, 0
+	}
+	slope = sxy / sxx
+	return my - slope*mx, slope
+}
+
+// ForecastLinear extrapolates a linear trend to at. The interval is the usual
+// regression prediction interval,
+//
+//	value ± t(0.95, n-2) * s * sqrt(1 + 1/n + (at - mean(x))² / Sxx)
+//
+// where s is the residual standard error and t the Student-t quantile with n-2
+// degrees of freedom. It needs at least three points; with one point the
+// forecast is that point and with two it is the line through them, both
+// without an interval. Without points there is nothing to extrapolate and it
+// returns nil.
+func ForecastLinear(x, y []float64, at float64) *Forecast {
+	n := len(x)
+	if n == 0 {
+		return nil
+	}
+
+	intercept, slope := LinearTrend(x, y)
+	value := intercept + slope*at
+	f := &Forecast{Value: value}
+	if n < 3 {
+		return f
+	}
+
+	mx := Mean(x)
+	sse, sxx := 0.0, 0.0
+	for i := range x {
+		r := y[i] - (intercept + slope*x[i])
+		sse += r * r
+		sxx += (x[i] - mx) * (x[i] - mx)
+	}
+	if sxx == 0 {
+		return f
+	}
+	s := math.Sqrt(sse / float64(n-2))
+	t := StudentTQuantile(1-(1-forecastLevel)/2, n-2)
+	width := t * s * math.Sqrt(1+1/float64(n)+(at-mx)*(at-mx)/sxx)
+
+	low, high := value-width, value+width
+	f.Low, f.High = &low, &high
+	return f
+}
+
+// Clamp limits a forecast and its interval to [low, high]. A nil forecast
+// stays nil.
+func (f *Forecast) Clamp(low, high float64) *Forecast {
+	if f == nil {
+		return nil
+	}
+	clamp := func(v float64) float64 {
+		return math.Min(math.Max(v, low), high)
+	}
+	clamped := &Forecast{Value: clamp(f.Value)}
+	if f.Low != nil && f.High != nil {
+		l, h := clamp(*f.Low), clamp(*f.High)
+		clamped.Low, clamped.High = &l, &h
+	}
+	return clamped
+}
+
+// StudentTQuantile returns the p-th quantile of Student's t distribution with
+// df degrees of freedom, found by bisection on its CDF
+func StudentTQuantile(p float64, df int) float64 {
+	if df <= 0 || p <= 0 || p >= 1 {
+		return math.NaN()
+	}
+	if p < 0.5 {
+		return -StudentTQuantile(1-p, df)
+	}
+
+	low, high := 0.0, 1.0
+	for studentTCDF(high, df) < p {
+		low, high = high, 2*high
+	}
+	for i := 0; i < 100; i++ {
+		mid := (low + high) / 2
+		if studentTCDF(mid, df) < p {
+			low = mid
+		} else {
+			high = mid
+		}
+	}
+	return (low + high) / 2
+}
+
+// studentTCDF returns P(T <= t) for Student's t distribution with df degrees
+// of freedom
+func studentTCDF(t float64, df int) float64 {
+	v := float64(df)
+	tail := 0.5 * regularizedBeta(v/(v+t*t), v/2, 0.5)
+	if t < 0 {
+		return tail
+	}
+	return 1 - tail
+}
+
+// regularizedBeta is the regularized incomplete beta function I_x(a, b), by
+// its continued fraction on whichever side converges faster
+func regularizedBeta(x, a, b float64) float64 {
+	if x <= 0 {
+		return 0
+	}
+	if x >= 1 {
+		return 1
+	}
+	la, _ := math.Lgamma(a)
+	lb, _ := math.Lgamma(b)
+	lab, _ := math.Lgamma(a + b)
+	front := math.Exp(lab - la - lb + a*math.Log(x) + b*math.Log(1-x))
+
+	if x < (a+1)/(a+b+2) {
+		return front * betaFraction(x, a, b) / a
+	}
+	return 1 - front*betaFraction(1-x, b, a)/b
+}
+
+// betaFraction evaluates the continued fraction of the incomplete beta
+// function with the modified Lentz's method
+func betaFraction(x, a, b float64) float64 {
+	const tiny = 1e-300
+	c := 1.0
+	d := 1 - (a+b)*x/(a+1)
+	if math.Abs(d) < tiny {
+		d = tiny
+	}
+	d = 1 / d
+	h := d
+	for m := 1; m < 500; m++ {
+		fm := float64(m)
+		for _, an := range []float64{
+			fm * (b - fm) * x / ((a + 2*fm - 1) * (a + 2*fm)),
+			-(a + fm) * (a + b + fm) * x / ((a + 2*fm) * (a + 2*fm + 1)),
+		} {
+			d = 1 + an*d
+			if math.Abs(d) < tiny {
+				d = tiny
+			}
+			c = 1 + an/c
+			if math.Abs(c) < tiny {
+				c = tiny
+			}
+			d = 1 / d
+			h *= d * c
+		}
+		if math.Abs(d*c-1) < 1e-14 {
+			break
+		}
+	}
+	return h
+}
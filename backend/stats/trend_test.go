@@ -0,0 +1,158 @@
+package stats
+
+import (
+	"math"
+	"testing"
+)
+
+func TestLinearTrend(t *testing.T) {
+	tests := []struct {
+		name          string
+		x, y          []float64
+		wantIntercept float64
+		wantSlope     float64
+	}{
+		{"no points", nil, nil, 0, 0},
+		{"one point", []float64{3}, []float64{7}, 7, 0},
+		{"exact line", []float64{1, 2, 3, 4}, []float64{3, 5, 7, 9}, 1, 2},
+		{"same x", []float64{2, 2}, []float64{1, 3}, 2, 0},
+		{"noisy", []float64{1, 2, 3}, []float64{1, 3, 2}, 1, 0.5},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			intercept, slope := LinearTrend(tt.x, tt.y)
+			if !near(intercept, tt.wantIntercept, 1e-12) || !near(slope, tt.wantSlope, 1e-12) {
+				t.Errorf("LinearTrend = (%v, %v), want (%v, %v)", intercept, slope, tt.wantIntercept, tt.wantSlope)
+			}
+		})
+	}
+}
+
+func TestForecastLinear(t *testing.T) {
+	tests := []struct {
+		name         string
+		x, y         []float64
+		at           float64
+		wantNil      bool
+		wantValue    float64
+		wantInterval bool
+	}{
+		{"no points", nil, nil, 5, true, 0, false},
+		{"one point", []float64{1}, []float64{4}, 5, false, 4, false},
+		{"two points", []float64{1, 2}, []float64{4, 6}, 3, false, 8, false},
+		{"exact line", []float64{1, 2, 3}, []float64{4, 6, 8}, 4, false, 10, true},
+		{"noisy", []float64{1, 2, 3, 4}, []float64{2, 5, 5, 8}, 5, false, 9.5, true},
+		{"same x", []float64{2, 2, 2}, []float64{1, 2, 3}, 3, false, 2, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := ForecastLinear(tt.x, tt.y, tt.at)
+			if f == nil {
+				if !tt.wantNil {
+					t.Fatal("ForecastLinear = nil, want a forecast")
+				}
+				return
+			}
+			if tt.wantNil {
+				t.Fatalf("ForecastLinear = %+v, want nil", *f)
+			}
+			if !near(f.Value, tt.wantValue, 1e-9) {
+				t.Errorf("value = %v, want %v", f.Value, tt.wantValue)
+			}
+			if (f.Low != nil) != tt.wantInterval || (f.High != nil) != tt.wantInterval {
+				t.Fatalf("interval present = %v, want %v", f.Low != nil, tt.wantInterval)
+			}
+			if tt.wantInterval && (*f.Low > f.Value || *f.High < f.Value) {
+				t.Errorf("interval [%v, %v] does not contain %v", *f.Low, *f.High, f.Value)
+			}
+		})
+	}
+}
+
+func TestForecastLinearInterval(t *testing.T) {
+	// Residuals -0.3, 0.9, -0.9, 0.3 give s = sqrt(1.8 / 2), and with n = 4,
+	// mean x = 2.5 and Sxx = 5 the half width at x = 5 is
+	// t(0.95, 2) * s * sqrt(1 + 1/4 + 6.25/5)
+	f := ForecastLinear([]float64{1, 2, 3, 4}, []float64{2, 5, 5, 8}, 5)
+	want := 2.919986 * math.Sqrt(0.9) * math.Sqrt(2.5)
+	if !near(*f.High-f.Value, want, 1e-5) || !near(f.Value-*f.Low, want, 1e-5) {
+		t.Errorf("interval = [%v, %v] around %v, want a half width of %v", *f.Low, *f.High, f.Value, want)
+	}
+}
+
+func TestForecastClamp(t *testing.T) {
+	var none *Forecast
+	if none.Clamp(0, 1) != nil {
+		t.Error("clamping a nil forecast should give nil")
+	}
+
+	low, high := -0.2, 0.7
+	f := (&Forecast{Value: 0.3, Low: &low, High: &high}).Clamp(0, 0.5)
+	if f.Value != 0.3 || *f.Low != 0 || *f.High != 0.5 {
+		t.Errorf("clamped = %v [%v, %v], want 0.3 [0, 0.5]", f.Value, *f.Low, *f.High)
+	}
+	if low != -0.2 || high != 0.7 {
+		t.Error("Clamp changed the original interval")
+	}
+}
+
+func TestStudentTQuantile(t *testing.T) {
+	tests := []struct {
+		p    float64
+		df   int
+		want float64
+	}{
+		{0.95, 1, 6.313752},
+		{0.95, 2, 2.919986},
+		{0.975, 5, 2.570582},
+		{0.95, 30, 1.697261},
+		{0.5, 10, 0},
+		{0.05, 2, -2.919986},
+		{0.995, 1000, 2.580755},
+	}
+
+	for _, tt := range tests {
+		if got := StudentTQuantile(tt.p, tt.df); !near(got, tt.want, 1e-5) {
+			t.Errorf("StudentTQuantile(%v, %d) = %v, want %v", tt.p, tt.df, got, tt.want)
+		}
+	}
+
+	for _, bad := range []struct {
+		p  float64
+		df int
+	}{{0, 5}, {1, 5}, {0.9, 0}} {
+		if got := StudentTQuantile(bad.p, bad.df); !math.IsNaN(got) {
+			t.Errorf("StudentTQuantile(%v, %d) = %v, want NaN", bad.p, bad.df, got)
+		}
+	}
+}
+
+func TestRegularizedBeta(t *testing.T) {
+	tests := []struct {
+		x, a, b float64
+		want    float64
+	}{
+		{0, 2, 3, 0},
+		{1, 2, 3, 1},
+		// I_x(1, 1) is the uniform CDF
+		{0.3, 1, 1, 0.3},
+		// I_x(a, 1) = x^a
+		{0.5, 3, 1, 0.125},
+		// I_x(2, 2) = 3x² - 2x³
+		{0.25, 2, 2, 0.15625},
+		{0.8, 2, 2, 0.896},
+	}
+
+	for _, tt := range tests {
+		if got := regularizedBeta(tt.x, tt.a, tt.b); !near(got, tt.want, 1e-10) {
+			t.Errorf("regularizedBeta(%v, %v, %v) = %v, want %v", tt.x, tt.a, tt.b, got, tt.want)
+		}
+	}
+}
+
+// near reports whether got is within tolerance of want
+func near(got, want, tolerance float64) bool {
+	return math.Abs(got-want) <= tolerance
+}
@@ -0,0 +1,165 @@
+package main
+
+import (
+	"net/http"
+	"sort"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/your-username/shark-tank-analytics/models"
+)
+
+// Dimensions compared by the team analytics endpoint
+var teamDimensions = []string{
+	"team_size", "company_age", "founder_count", "founder_gender",
+	"founder_age_band", "repeat_founder",
+}
+
+// getTeamAnalytics compares deal rates and capital across team compositions
+// and company ages, one table per dimension with a test of whether its deal
+// rates differ. Deals can be filtered by any analytics dimension.
+func getTeamAnalytics(c *gin.Context) {
+	deals, err := loadDeals("")
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	deals, filters := filterDeals(c, deals)
+
+	metrics, _ := analyticsMetricsFor("count,deals,deal_rate,total_capital,median_deal_amount")
+
+	tables := gin.H{}
+	for _, name := range teamDimensions {
+		dimension, _ := findDimension(name)
+
+		groups := make(map[string][]models.Deal)
+		for _, deal := range deals {
+			for _, key := range dimension.keys(deal) {
+				groups[key] = append(groups[key], deal)
+			}
+		}
+
+		rows := make([]gin.H, 0, len(groups))
+		grouped := make([][]models.Deal, 0, len(groups))
+		for _, key := range sortedGroupNames(groups) {
+			row := computeMetrics(groups[key], metrics)
+			row[name] = key
+			rows = append(rows, row)
+			grouped = append(grouped, groups[key])
+		}
+		tables[name] = gin.H{
+			"groups":     rows,
+			"comparison": compareDealRates(grouped, []analyticsDimension{dimension}),
+		}
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"filters": filters,
+		"tables":  tables,
+	})
+}
+
+// Helper functions
+
+func teamSizeBand(size int) string {
+	switch {
+	case size <= 0:
+		return "Unknown"
+	case size <= 5:
+		return "1-5"
+	case size <= 20:
+		return "6-20"
+	case size <= 50:
+		return "21-50"
+	default:
+		return "51+"
+	}
+}
+
+// companyAge is the age of the company in the year its pitch aired
+func companyAge(deal models.Deal) (int, bool) {
+	if deal.AirDate == nil || deal.FoundedYear <= 0 || deal.FoundedYear > deal.AirDate.Year() {
+		return 0, false
+	}
+	return deal.AirDate.Year() - deal.FoundedYear, true
+}
+
+func companyAgeBand(deal models.Deal) string {
+	age, ok := companyAge(deal)
+	switch {
+	case !ok:
+		return "Unknown"
+	case age < 1:
+		return "under 1 year"
+	case age <= 2:
+		return "1-2 years"
+	case age <= 5:
+		return "3-5 years"
+	default:
+		return "6+ years"
+	}
+}
+
+func founderCount(founders []models.Founder) string {
+	switch len(founders) {
+	case 0:
+		return "Unknown"
+	case 1, 2:
+		return strconv.Itoa(len(founders))
+	default:
+		return "3+"
+	}
+}
+
+// founderGenderMix is "all male", "all female", "mixed" or "Unknown" when any
+// founder's gender is not recorded
+func founderGenderMix(founders []models.Founder) string {
+	if len(founders) == 0 {
+		return "Unknown"
+	}
+	genders := make(map[string]bool)
+	for _, founder := range founders {
+		if founder.Gender == "" {
+			return "Unknown"
+		}
+		genders[founder.Gender] = true
+	}
+	if len(genders) > 1 {
+		return "mixed"
+	}
+	for gender := range genders {
+		return "all " + gender
+	}
+	return "Unknown"
+}
+
+func founderAgeBands(founders []models.Founder) []string {
+	seen := make(map[string]bool)
+	for _, founder := range founders {
+		if founder.AgeBand != "" {
+			seen[founder.AgeBand] = true
+		}
+	}
+	if len(seen) == 0 {
+		return []string{"Unknown"}
+	}
+
+	bands := make([]string, 0, len(seen))
+	for band := range seen {
+		bands = append(bands, band)
+	}
+	sort.Strings(bands)
+	return bands
+}
+
+func repeatFounder(founders []models.Founder) string {
+	if len(founders) == 0 {
+		return "Unknown"
+	}
+	for _, founder := range founders {
+		if founder.RepeatFounder {
+			return "repeat"
+		}
+	}
+	return "first-time"
+}
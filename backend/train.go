@@ -0,0 +1,133 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/your-username/shark-tank-analytics/ml"
+)
+
+const modelPrefix = "deal-model-"
+
+// modelDir returns where model artifacts are stored, overridable with MODEL_DIR
+func modelDir() string {
+	if dir := os.Getenv("MODEL_DIR"); dir != "" {
+		return dir
+	}
+	return "artifacts"
+}
+
+// modelPath returns the artifact of a model version
+func modelPath(version string) string {
+	return filepath.Join(modelDir(), modelPrefix+version+".json")
+}
+
+// reportPath returns the evaluation report of a model version
+func reportPath(version string) string {
+	return filepath.Join(modelDir(), modelPrefix+version+"-report.json")
+}
+
+// modelVersion returns the model version to serve: MODEL_VERSION when set,
+// otherwise the most recently trained artifact
+func modelVersion() (string, error) {
+	if version := os.Getenv("MODEL_VERSION"); version != "" {
+		return version, nil
+	}
+
+	paths, err := filepath.Glob(filepath.Join(modelDir(), modelPrefix+"*.json"))
+	if err != nil {
+		return "", err
+	}
+
+	// Versions are free-form labels, so order by when each model was trained
+	latest, latestTrained := "", time.Time{}
+	for _, path := range paths {
+		name := strings.TrimSuffix(filepath.Base(path), ".json")
+		if strings.HasSuffix(name, "-report") {
+			continue
+		}
+		trained, err := trainedAt(path)
+		if err != nil {
+			log.Printf("Skipping model artifact %s: %v", path, err)
+			continue
+		}
+		if latest == "" || trained.After(latestTrained) {
+			latest, latestTrained = strings.TrimPrefix(name, modelPrefix), trained
+		}
+	}
+	if latest == "" {
+		return "", fmt.Errorf("no model artifacts in %s", modelDir())
+	}
+	return latest, nil
+}
+
+// trainedAt reads the training time stamped on a model artifact
+func trainedAt(path string) (time.Time, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	var artifact struct {
+		TrainedAt time.Time `json:"trained_at"`
+	}
+	if err := json.Unmarshal(data, &artifact); err != nil {
+		return time.Time{}, err
+	}
+	return artifact.TrainedAt, nil
+}
+
+// trainModel implements the train subcommand: it cross-validates the deal
+// outcome model, fits it on every stored deal and writes the versioned model
+// artifact and its evaluation report
+func trainModel(args []string) {
+	flags := flag.NewFlagSet("train", flag.ExitOnError)
+	folds := flags.Int("folds", 5, "number of cross-validation folds")
+	version := flags.String("version", time.Now().UTC().Format("20060102-150405"), "version to stamp on the model")
+	flags.Parse(args)
+	if !ml.ValidVersion(*version) {
+		log.Fatalf("Invalid version %q, use letters, digits, dots, dashes and underscores", *version)
+	}
+
+	deals, err := loadDeals("")
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	report, err := ml.CrossValidate(deals, *folds, ml.DefaultOptions)
+	if err != nil {
+		log.Fatal(err)
+	}
+	report.Version = *version
+
+	model, err := ml.Train(deals, ml.DefaultOptions)
+	if err != nil {
+		log.Fatal(err)
+	}
+	model.Version = *version
+
+	if err := os.MkdirAll(modelDir(), 0755); err != nil {
+		log.Fatal(err)
+	}
+	if err := model.Save(modelPath(*version)); err != nil {
+		log.Fatal(err)
+	}
+
+	data, err := json.MarshalIndent(report, "", "  ")
+	if err != nil {
+		log.Fatal(err)
+	}
+	if err := os.WriteFile(reportPath(*version), data, 0644); err != nil {
+		log.Fatal(err)
+	}
+
+	log.Printf("Trained deal model %s on %d deals", model.Version, model.TrainingSize)
+	log.Printf("%d-fold AUC %.3f, accuracy %.3f, Brier score %.3f", report.Folds, report.AUC, report.Accuracy, report.BrierScore)
+	log.Printf("Saved %s and %s", modelPath(*version), reportPath(*version))
+}
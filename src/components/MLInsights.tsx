@@ -1,11 +1,45 @@
-import React from 'react';
-import { Brain, TrendingUp, Target, AlertTriangle } from 'lucide-react';
+import React, { useState } from 'react';
+import { Brain, TrendingUp, Target, AlertTriangle, HelpCircle } from 'lucide-react';
 import { useDealsStore } from '../store/useDealsStore';
 import { useThemeStore } from '../store/useThemeStore';
 import { useAuthStore } from '../store/useAuthStore';
+import { fetchDealPredictionHistory, fetchPredictionExplanation } from '../lib/api';
+
+interface Explanation {
+  model_version: string;
+  reasons: string[];
+  similar_deals: {
+    deal_id: number;
+    startup_name: string;
+    industry: string;
+    got_deal: boolean;
+    similarity: number;
+  }[];
+}
 
 export const MLInsights: React.FC = () => {
-  const { predictions, insights } = useDealsStore();
+  const { predictions, insights, deals } = useDealsStore();
+  const [dealId, setDealId] = useState<number | null>(null);
+  const [explanation, setExplanation] = useState<Explanation | null>(null);
+  const [explainError, setExplainError] = useState<string | null>(null);
+
+  // Explain the latest stored prediction of the chosen deal
+  const explainDeal = async (id: number) => {
+    setDealId(id);
+    setExplanation(null);
+    setExplainError(null);
+    try {
+      const history = await fetchDealPredictionHistory(id);
+      if (!history?.length) {
+        setExplainError('No prediction has been stored for this deal; deals the model was trained on are not scored');
+        return;
+      }
+      setExplanation(await fetchPredictionExplanation(history[history.length - 1].id));
+    } catch (error) {
+      console.error('Explain prediction error:', error);
+      setExplainError('Failed to load the explanation');
+    }
+  };
   const { isDarkMode } = useThemeStore();
   const { user } = useAuthStore();
 
@@ -68,6 +102,66 @@ export const MLInsights: React.FC = () => {
         </div>
       </div>
 
+      <div className={`p-6 rounded-lg ${
+        isDarkMode ? 'bg-[#1E2A3B]' : 'bg-white'
+      } shadow-lg`}>
+        <h3 className="text-xl font-bold mb-4 flex items-center">
+          <HelpCircle className="h-6 w-6 mr-2" />
+          Why This Prediction?
+        </h3>
+
+        <select
+          value={dealId ?? ''}
+          onChange={(e) => e.target.value && explainDeal(Number(e.target.value))}
+          className={`w-full p-2 rounded-lg mb-4 ${
+            isDarkMode ? 'bg-[#0D1B2A] text-white' : 'bg-[#F5F5F5] text-gray-900'
+          }`}
+        >
+          <option value="">Choose a deal</option>
+          {deals.map((deal) => (
+            <option key={deal.id} value={deal.id}>
+              {deal.startup_name} (S{deal.season}E{deal.episode})
+            </option>
+          ))}
+        </select>
+
+        {explainError && (
+          <p className={`${isDarkMode ? 'text-gray-400' : 'text-gray-600'}`}>{explainError}</p>
+        )}
+
+        {explanation && (
+          <div className="space-y-4">
+            <ul className="list-disc list-inside space-y-1">
+              {explanation.reasons.map((reason) => (
+                <li key={reason}>{reason}</li>
+              ))}
+            </ul>
+
+            <div>
+              <h4 className="font-semibold mb-2">Most Similar Past Deals</h4>
+              <div className="space-y-2">
+                {explanation.similar_deals.map((similar) => (
+                  <div key={similar.deal_id} className={`p-3 rounded-lg flex items-center justify-between ${
+                    isDarkMode ? 'bg-[#0D1B2A]' : 'bg-[#F5F5F5]'
+                  }`}>
+                    <span>{similar.startup_name} <span className="text-sm text-gray-500">{similar.industry}</span></span>
+                    <span className={`px-3 py-1 rounded-full text-sm ${
+                      similar.got_deal
+                        ? 'bg-green-500 bg-opacity-20 text-green-500'
+                        : 'bg-red-500 bg-opacity-20 text-red-500'
+                    }`}>
+                      {similar.got_deal ? 'Deal' : 'No deal'}
+                    </span>
+                  </div>
+                ))}
+              </div>
+            </div>
+
+            <p className="text-sm text-gray-500">Model version {explanation.model_version}</p>
+          </div>
+        )}
+      </div>
+
       <div className={`p-6 rounded-lg ${
         isDarkMode ? 'bg-[#1E2A3B]' : 'bg-white'
       } shadow-lg`}>
@@ -148,4 +148,14 @@ export const fetchPredictions = () => withRetry(async () => {
   return response.data;
 });
 
+export const fetchDealPredictionHistory = (dealId: number) => withRetry(async () => {
+  const response = await api.get(`/deals/${dealId}/predictions`);
+  return response.data;
+});
+
+export const fetchPredictionExplanation = (id: number) => withRetry(async () => {
+  const response = await api.get(`/predictions/${id}/explain`);
+  return response.data;
+});
+
 export { api };
\ No newline at end of file
@@ -19,9 +19,9 @@ interface Deal {
   success_status: string;
   pitch_description: string;
   product_category: string;
-  revenue_current: number;
+  revenue_current: number | null;
   revenue_projected: number;
-  profit_margin: number;
+  profit_margin: number | null;
   team_size: number;
   founded_year: number;
   location: string;